@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,6 +41,37 @@ func (r *Registration[T]) Multi() *Registration[T] {
 	return r
 }
 
+// Priority orders Multi entries in ResolveAll: higher values come first, ties keep
+// registration order. Default: 0.
+func (r *Registration[T]) Priority(p int) *Registration[T] {
+	r.e.priority = p
+	return r
+}
+
+// OnlyIf makes the provider eligible only while cond returns true.
+func (r *Registration[T]) OnlyIf(cond func() bool) *Registration[T] {
+	if cond == nil {
+		panic("di: OnlyIf requires a non-nil condition")
+	}
+	r.e.conds = append(r.e.conds, cond)
+	return r
+}
+
+// Profile makes the provider eligible only when one of the given profiles is active.
+func (r *Registration[T]) Profile(names ...string) *Registration[T] {
+	if len(names) == 0 {
+		panic("di: Profile requires at least one name")
+	}
+	r.e.profiles = append(r.e.profiles, names...)
+	return r
+}
+
+// Fallback marks the provider as used only when no other eligible provider matches.
+func (r *Registration[T]) Fallback() *Registration[T] {
+	r.e.fallback = true
+	return r
+}
+
 func (r *Registration[T]) OnStart(fn func(T) error) *Registration[T] {
 	r.e.onStart = func(v any) error { return fn(v.(T)) }
 	addToLifecycle(r.e)
@@ -61,6 +93,11 @@ type entry struct {
 	scope       Scope
 	scopeLocked bool
 	multi       bool
+	priority    int
+	seq         uint64
+	conds       []func() bool
+	profiles    []string
+	fallback    bool
 
 	once      sync.Once
 	cached    any
@@ -74,12 +111,45 @@ type entry struct {
 
 var (
 	mu    sync.RWMutex
-	store = map[reflect.Type]map[string]*entry{}
+	store = map[reflect.Type]map[string][]*entry{}
+	seq   uint64
 
 	lcMu  sync.RWMutex
 	lcAll []*entry
+
+	profMu   sync.RWMutex
+	profiles = map[string]bool{}
 )
 
+// conditional reports whether the provider has any OnlyIf, Profile or Fallback restriction.
+func (e *entry) conditional() bool {
+	return len(e.conds) > 0 || len(e.profiles) > 0 || e.fallback
+}
+
+// eligible reports whether all conditions and profiles of the provider are satisfied.
+func (e *entry) eligible() bool {
+	if len(e.profiles) > 0 {
+		profMu.RLock()
+		ok := false
+		for _, p := range e.profiles {
+			if profiles[p] {
+				ok = true
+				break
+			}
+		}
+		profMu.RUnlock()
+		if !ok {
+			return false
+		}
+	}
+	for _, cond := range e.conds {
+		if !cond() {
+			return false
+		}
+	}
+	return true
+}
+
 func addToLifecycle(e *entry) {
 	if e.inLifecycle {
 		return
@@ -90,7 +160,10 @@ func addToLifecycle(e *entry) {
 	lcMu.Unlock()
 }
 
-type builderImpl[T any] struct{ typ reflect.Type }
+type builderImpl[T any] struct {
+	typ   reflect.Type
+	added []*entry
+}
 
 func (b *builderImpl[T]) New(ctor func() (T, error)) *Registration[T] {
 	return b.add("", func() (any, error) { return ctor() }, ScopeSingleton, false)
@@ -129,20 +202,48 @@ func (b *builderImpl[T]) add(key string, factory func() (any, error), scope Scop
 		scope:       scope,
 		scopeLocked: locked,
 	}
+	b.added = append(b.added, e)
+	return &Registration[T]{e: e}
+}
+
+// commit stores the configured providers. Providers restricted by OnlyIf or Profile, and
+// unnamed Multi entries, may share a key; two unconditional providers or two Fallbacks
+// for the same key panic.
+func (b *builderImpl[T]) commit() {
 	mu.Lock()
-	if store[b.typ] == nil {
-		store[b.typ] = make(map[string]*entry)
+	defer mu.Unlock()
+	m := store[b.typ]
+	for i, e := range b.added {
+		for _, other := range m[e.key] {
+			checkDuplicate(e, other)
+		}
+		for _, other := range b.added[:i] {
+			if other.key == e.key {
+				checkDuplicate(e, other)
+			}
+		}
+	}
+	if m == nil {
+		m = make(map[string][]*entry)
+		store[b.typ] = m
 	}
-	if _, exists := store[b.typ][key]; exists {
-		mu.Unlock()
-		if key == "" {
-			panic(fmt.Sprintf("di: unnamed provider for %v already registered", b.typ))
+	for _, e := range b.added {
+		seq++
+		e.seq = seq
+		m[e.key] = append(m[e.key], e)
+	}
+}
+
+func checkDuplicate(e, other *entry) {
+	if e.key == "" && e.multi && other.multi {
+		return
+	}
+	if (e.fallback && other.fallback) || (!e.conditional() && !other.conditional()) {
+		if e.key == "" {
+			panic(fmt.Sprintf("di: unnamed provider for %v already registered", e.typ))
 		}
-		panic(fmt.Sprintf("di: provider named %q for %v already registered", key, b.typ))
+		panic(fmt.Sprintf("di: provider named %q for %v already registered", e.key, e.typ))
 	}
-	store[b.typ][key] = e
-	mu.Unlock()
-	return &Registration[T]{e: e}
 }
 
 // Register configures one provider for type T via the builder.
@@ -150,7 +251,31 @@ func Register[T any](configurator func(Builder[T])) {
 	if configurator == nil {
 		return
 	}
-	configurator(&builderImpl[T]{typ: reflect.TypeFor[T]()})
+	b := &builderImpl[T]{typ: reflect.TypeFor[T]()}
+	configurator(b)
+	b.commit()
+}
+
+// SetProfiles replaces the set of active profiles used by Profile registrations.
+func SetProfiles(names ...string) {
+	profMu.Lock()
+	profiles = make(map[string]bool, len(names))
+	for _, n := range names {
+		profiles[n] = true
+	}
+	profMu.Unlock()
+}
+
+// ActiveProfiles returns the currently active profiles, sorted by name.
+func ActiveProfiles() []string {
+	profMu.RLock()
+	out := make([]string, 0, len(profiles))
+	for n := range profiles {
+		out = append(out, n)
+	}
+	profMu.RUnlock()
+	sort.Strings(out)
+	return out
 }
 
 // Resolve returns the default (unnamed) instance of T. Panics if not registered.
@@ -166,46 +291,57 @@ func ResolveNamed[T any](name string) T {
 // TryResolve returns the default instance and true, or zero value and false if not registered.
 func TryResolve[T any]() (T, bool) {
 	var zero T
-	mu.RLock()
-	m := store[reflect.TypeFor[T]()]
-	mu.RUnlock()
-	if m == nil || m[""] == nil {
+	e := lookup(reflect.TypeFor[T](), "")
+	if e == nil {
 		return zero, false
 	}
-	return buildEntry(m[""]).(T), true
+	return buildEntry(e).(T), true
 }
 
 // TryResolveNamed returns the named instance and true, or zero value and false if not registered.
 func TryResolveNamed[T any](name string) (T, bool) {
 	var zero T
-	mu.RLock()
-	m := store[reflect.TypeFor[T]()]
-	mu.RUnlock()
-	if m == nil || m[name] == nil {
+	e := lookup(reflect.TypeFor[T](), name)
+	if e == nil {
 		return zero, false
 	}
-	return buildEntry(m[name]).(T), true
+	return buildEntry(e).(T), true
 }
 
-// ResolveAll returns all instances of T marked with Multi().
+// ResolveAll returns all instances of T marked with Multi(), ordered by Priority and then
+// by registration order.
 func ResolveAll[T any]() []T {
-	mu.RLock()
-	m := store[reflect.TypeFor[T]()]
-	mu.RUnlock()
-	var out []T
-	for _, e := range m {
-		if e.multi {
-			out = append(out, buildEntry(e).(T))
+	list := multiEntries(reflect.TypeFor[T]())
+	out := make([]T, 0, len(list))
+	for _, e := range list {
+		out = append(out, buildEntry(e).(T))
+	}
+	return out
+}
+
+// ResolveMap returns the named instances of T marked with Multi(), keyed by name.
+func ResolveMap[T any]() map[string]T {
+	out := map[string]T{}
+	for _, e := range multiEntries(reflect.TypeFor[T]()) {
+		if e.key == "" {
+			continue
+		}
+		if _, exists := out[e.key]; exists {
+			panic(fmt.Sprintf("di: ambiguous providers named %q for %v", e.key, e.typ))
 		}
+		out[e.key] = buildEntry(e).(T)
 	}
 	return out
 }
 
-// Reset clears all registrations and lifecycle state. Use in tests.
+// Reset clears all registrations, active profiles and lifecycle state. Use in tests.
 func Reset() {
 	mu.Lock()
-	store = make(map[reflect.Type]map[string]*entry)
+	store = make(map[reflect.Type]map[string][]*entry)
 	mu.Unlock()
+	profMu.Lock()
+	profiles = make(map[string]bool)
+	profMu.Unlock()
 	lcMu.Lock()
 	lcAll = nil
 	lcMu.Unlock()
@@ -214,23 +350,109 @@ func Reset() {
 // --- internal resolution ---
 
 func resolveType(typ reflect.Type) any {
-	mu.RLock()
-	m := store[typ]
-	mu.RUnlock()
-	if m == nil || m[""] == nil {
+	e := lookup(typ, "")
+	if e == nil {
 		panic(fmt.Sprintf("di: no provider registered for %v", typ))
 	}
-	return buildEntry(m[""])
+	return buildEntry(e)
 }
 
 func resolveNamed(typ reflect.Type, name string) any {
+	e := lookup(typ, name)
+	if e == nil {
+		panic(fmt.Sprintf("di: no provider named %q for %v", name, typ))
+	}
+	return buildEntry(e)
+}
+
+// lookup selects the eligible provider for typ and key, or nil if none matches.
+func lookup(typ reflect.Type, key string) *entry {
 	mu.RLock()
-	m := store[typ]
+	list := store[typ][key]
 	mu.RUnlock()
-	if m == nil || m[name] == nil {
-		panic(fmt.Sprintf("di: no provider named %q for %v", name, typ))
+	return pick(list)
+}
+
+// pick returns the single eligible non-fallback provider, or the eligible fallback when
+// none matches. Panics if more than one non-fallback provider is eligible.
+func pick(list []*entry) *entry {
+	var found, fallback *entry
+	for _, e := range list {
+		if !e.eligible() {
+			continue
+		}
+		if e.fallback {
+			if fallback == nil {
+				fallback = e
+			}
+			continue
+		}
+		if found != nil {
+			if e.key == "" {
+				panic(fmt.Sprintf("di: ambiguous unnamed providers for %v", e.typ))
+			}
+			panic(fmt.Sprintf("di: ambiguous providers named %q for %v", e.key, e.typ))
+		}
+		found = e
+	}
+	if found != nil {
+		return found
+	}
+	return fallback
+}
+
+// multiEntries returns the eligible Multi entries of typ in resolution order. A Fallback
+// entry is kept only when no other eligible Multi entry shares its name.
+func multiEntries(typ reflect.Type) []*entry {
+	mu.RLock()
+	var list []*entry
+	for _, entries := range store[typ] {
+		for _, e := range entries {
+			if e.multi {
+				list = append(list, e)
+			}
+		}
+	}
+	mu.RUnlock()
+
+	var out, fallbacks []*entry
+	matched := map[string]bool{}
+	for _, e := range list {
+		if !e.eligible() {
+			continue
+		}
+		if e.fallback {
+			fallbacks = append(fallbacks, e)
+			continue
+		}
+		matched[e.key] = true
+		out = append(out, e)
+	}
+	for _, e := range fallbacks {
+		if !matched[e.key] {
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].priority != out[j].priority {
+			return out[i].priority > out[j].priority
+		}
+		return out[i].seq < out[j].seq
+	})
+	return out
+}
+
+// selected reports whether e is the provider currently chosen for its type and key.
+func selected(e *entry) bool {
+	if e.multi {
+		for _, m := range multiEntries(e.typ) {
+			if m == e {
+				return true
+			}
+		}
+		return false
 	}
-	return buildEntry(m[name])
+	return lookup(e.typ, e.key) == e
 }
 
 func buildEntry(e *entry) any {
@@ -277,7 +499,7 @@ func StartAllWithContext(ctx context.Context) error {
 
 	var started []*entry
 	for _, e := range list {
-		if e.onStart == nil || e.started.Load() {
+		if e.onStart == nil || e.started.Load() || !selected(e) {
 			continue
 		}
 		select {
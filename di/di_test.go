@@ -3,6 +3,7 @@ package di_test
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/leandroluk/gox/di"
@@ -176,6 +177,70 @@ func TestMulti_EmptyWhenNoneMarked(t *testing.T) {
 	}
 }
 
+func TestMulti_RegistrationOrder(t *testing.T) {
+	defer di.Reset()
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		n := name
+		di.Register[greeter](func(b di.Builder[greeter]) {
+			b.New(func() (greeter, error) { return &greeterImpl{msg: n}, nil }).Multi()
+		})
+	}
+	di.Register[greeter](func(b di.Builder[greeter]) {
+		b.Named("f", func() (greeter, error) { return &greeterImpl{msg: "f"}, nil }).Multi()
+	})
+	for range 10 {
+		var got string
+		for _, g := range di.ResolveAll[greeter]() {
+			got += g.Greet()
+		}
+		if got != "abcdef" {
+			t.Fatalf("ResolveAll: expected abcdef, got %q", got)
+		}
+	}
+}
+
+func TestMulti_Priority(t *testing.T) {
+	defer di.Reset()
+	di.Register[greeter](func(b di.Builder[greeter]) {
+		b.Named("low", func() (greeter, error) { return &greeterImpl{msg: "low"}, nil }).Multi().Priority(-1)
+		b.Named("plain", func() (greeter, error) { return &greeterImpl{msg: "plain"}, nil }).Multi()
+		b.Named("high", func() (greeter, error) { return &greeterImpl{msg: "high"}, nil }).Multi().Priority(10)
+	})
+	all := di.ResolveAll[greeter]()
+	want := []string{"high", "plain", "low"}
+	for i, w := range want {
+		if all[i].Greet() != w {
+			t.Fatalf("priority: all[%d]=%q want %q", i, all[i].Greet(), w)
+		}
+	}
+}
+
+func TestMulti_ResolveMap(t *testing.T) {
+	defer di.Reset()
+	di.Register[greeter](func(b di.Builder[greeter]) {
+		b.Named("en", func() (greeter, error) { return &greeterImpl{msg: "hello"}, nil }).Multi()
+		b.Named("pt", func() (greeter, error) { return &greeterImpl{msg: "olá"}, nil }).Multi()
+		b.Named("es", func() (greeter, error) { return &greeterImpl{msg: "hola"}, nil })
+		b.New(func() (greeter, error) { return &greeterImpl{msg: "anon"}, nil }).Multi()
+	})
+	m := di.ResolveMap[greeter]()
+	if len(m) != 2 || m["en"].Greet() != "hello" || m["pt"].Greet() != "olá" {
+		t.Fatalf("ResolveMap: unexpected %v", m)
+	}
+}
+
+func TestMulti_SkipsIneligible(t *testing.T) {
+	defer di.Reset()
+	di.Register[greeter](func(b di.Builder[greeter]) {
+		b.New(func() (greeter, error) { return &greeterImpl{msg: "test"}, nil }).Multi().Profile("test")
+		b.New(func() (greeter, error) { return &greeterImpl{msg: "always"}, nil }).Multi()
+	})
+	all := di.ResolveAll[greeter]()
+	if len(all) != 1 || all[0].Greet() != "always" {
+		t.Fatalf("ResolveAll: expected [always], got %d entries", len(all))
+	}
+}
+
 // --- TryResolve ---
 
 func TestTryResolve_Found(t *testing.T) {
@@ -339,6 +404,131 @@ func TestInitSelectorPattern(t *testing.T) {
 	}
 }
 
+// --- conditional ---
+
+type repo interface{ Kind() string }
+type repoImpl struct{ kind string }
+
+func (r *repoImpl) Kind() string { return r.kind }
+
+func registerRepos() {
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.New(func() (repo, error) { return &repoImpl{kind: "memory"}, nil }).Profile("test")
+	})
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.New(func() (repo, error) { return &repoImpl{kind: "postgres"}, nil }).Profile("prod")
+	})
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.New(func() (repo, error) { return &repoImpl{kind: "noop"}, nil }).Fallback()
+	})
+}
+
+func TestProfile_SelectsActiveProvider(t *testing.T) {
+	defer di.Reset()
+	registerRepos()
+	di.SetProfiles("test")
+	if got := di.Resolve[repo]().Kind(); got != "memory" {
+		t.Fatalf("profile test: got %q", got)
+	}
+}
+
+func TestProfile_ActiveProfilesSorted(t *testing.T) {
+	defer di.Reset()
+	di.SetProfiles("test", "prod", "dev")
+	if got := di.ActiveProfiles(); !reflect.DeepEqual(got, []string{"dev", "prod", "test"}) {
+		t.Fatalf("active profiles: got %v", got)
+	}
+}
+
+func TestProfile_FallbackWhenNoneMatches(t *testing.T) {
+	defer di.Reset()
+	registerRepos()
+	di.SetProfiles("staging")
+	if got := di.Resolve[repo]().Kind(); got != "noop" {
+		t.Fatalf("fallback: got %q", got)
+	}
+}
+
+func TestProfile_NotFoundWithoutFallback(t *testing.T) {
+	defer di.Reset()
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.New(func() (repo, error) { return &repoImpl{kind: "memory"}, nil }).Profile("test")
+	})
+	if _, ok := di.TryResolve[repo](); ok {
+		t.Fatal("profile: expected no eligible provider")
+	}
+}
+
+func TestOnlyIf_SelectsByCondition(t *testing.T) {
+	defer di.Reset()
+	useCache := false
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.Named("store", func() (repo, error) { return &repoImpl{kind: "cached"}, nil }).
+			OnlyIf(func() bool { return useCache })
+		b.Named("store", func() (repo, error) { return &repoImpl{kind: "direct"}, nil }).
+			OnlyIf(func() bool { return !useCache })
+	})
+	if got := di.ResolveNamed[repo]("store").Kind(); got != "direct" {
+		t.Fatalf("OnlyIf: got %q", got)
+	}
+}
+
+func TestOnlyIf_UnconditionalWinsOverFallback(t *testing.T) {
+	defer di.Reset()
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.New(func() (repo, error) { return &repoImpl{kind: "default"}, nil }).Fallback()
+		b.New(func() (repo, error) { return &repoImpl{kind: "main"}, nil })
+	})
+	if got := di.Resolve[repo]().Kind(); got != "main" {
+		t.Fatalf("fallback: got %q", got)
+	}
+}
+
+func TestProfile_LifecycleSkipsInactive(t *testing.T) {
+	defer di.Reset()
+	var started []string
+	for _, p := range []string{"test", "prod"} {
+		profile := p
+		di.Register[repo](func(b di.Builder[repo]) {
+			b.New(func() (repo, error) { return &repoImpl{kind: profile}, nil }).
+				Profile(profile).
+				OnStart(func(r repo) error { started = append(started, r.Kind()); return nil })
+		})
+	}
+	di.SetProfiles("prod")
+	if err := di.StartAll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(started) != 1 || started[0] != "prod" {
+		t.Fatalf("lifecycle: expected [prod], got %v", started)
+	}
+}
+
+func TestPanic_AmbiguousProfiles(t *testing.T) {
+	defer di.Reset()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic for ambiguous providers")
+		}
+	}()
+	registerRepos()
+	di.SetProfiles("test", "prod")
+	di.Resolve[repo]()
+}
+
+func TestPanic_DuplicateFallback(t *testing.T) {
+	defer di.Reset()
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic on duplicate fallback")
+		}
+	}()
+	di.Register[repo](func(b di.Builder[repo]) {
+		b.New(func() (repo, error) { return &repoImpl{}, nil }).Fallback()
+		b.New(func() (repo, error) { return &repoImpl{}, nil }).Fallback()
+	})
+}
+
 // --- reset ---
 
 func TestReset_ClearsAll(t *testing.T) {
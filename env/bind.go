@@ -0,0 +1,190 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindError aggregates every variable that could not be bound by Bind.
+type BindError struct {
+	Errors []error
+}
+
+func (e *BindError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "env: %d variable(s) could not be bound", len(e.Errors))
+	for _, err := range e.Errors {
+		sb.WriteString("\n  ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+func (e *BindError) Unwrap() []error {
+	return e.Errors
+}
+
+// Bind populates a struct of type T from environment variables using field tags:
+//
+//	env:"DB_HOST"       variable name (use "-" to skip the field)
+//	default:"localhost" value used when the variable is missing or blank
+//	required:"true"     report an error when the variable is missing and has no default
+//	prefix:"DB_"        prefix applied to the variables of a nested struct
+//	sep:";"             separator for slice items and map pairs (default ",")
+//
+// Every missing or unparsable variable is reported in a single *BindError holding
+// *MissingError and *ParseError values. Once every variable is bound, the struct is
+// validated with the schema registered for T in the validate package, if any, and
+// each issue is reported as an *InvalidError.
+func Bind[T any]() (T, error) {
+	return BindFrom[T](defaultEnv)
+}
+
+// BindTo populates the struct pointed to by ptr. See Bind for the supported tags.
+func BindTo(ptr any) error {
+	return bindTo(defaultEnv, ptr)
+}
+
+func bindTo(e *Environment, ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: BindTo requires a non-nil pointer to a struct, got %T", ptr)
+	}
+	var errs []error
+	bindStruct(e, rv.Elem(), "", &errs)
+	if len(errs) == 0 {
+		errs = validateBound(rv.Elem())
+	}
+	if len(errs) > 0 {
+		return &BindError{Errors: errs}
+	}
+	return nil
+}
+
+func bindStruct(e *Environment, sv reflect.Value, prefix string, errs *[]error) {
+	visitFields(sv, prefix, true, func(f boundField) {
+		raw, found, err := e.resolve(f.Key)
+		if err != nil {
+			*errs = append(*errs, err)
+			return
+		}
+		if !found || strings.TrimSpace(raw) == "" {
+			def, hasDefault := f.Field.Tag.Lookup("default")
+			if !hasDefault {
+				if f.Field.Tag.Get("required") == "true" {
+					*errs = append(*errs, &MissingError{Key: f.Key})
+				}
+				return
+			}
+			raw = e.expand(def)
+		}
+
+		val, err := convertWithSeparator(raw, f.Field.Type, fieldSeparator(f.Field))
+		if err != nil {
+			*errs = append(*errs, &ParseError{Key: f.Key, Raw: raw, Type: f.Field.Type, Err: err})
+			return
+		}
+		f.Value.Set(val)
+	})
+}
+
+// boundField is a struct field mapped to an environment variable. Path is the
+// dotted path of the field by JSON name, as reported by validate, or "" when the
+// field is not encoded.
+type boundField struct {
+	Key   string
+	Path  string
+	Field reflect.StructField
+	Value reflect.Value
+}
+
+// visitFields calls fn for every field of sv mapped to a variable, descending into
+// nested structs with their prefix. Nil nested pointers are allocated when alloc is
+// set and skipped otherwise.
+func visitFields(sv reflect.Value, prefix string, alloc bool, fn func(boundField)) {
+	walkFields(sv, prefix, "", alloc, fn)
+}
+
+func walkFields(sv reflect.Value, prefix, path string, alloc bool, fn func(boundField)) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, hasTag := field.Tag.Lookup("env")
+		if tag == "-" {
+			continue
+		}
+		fv := sv.Field(i)
+		fieldPath := joinPath(path, jsonName(field))
+
+		if hasTag {
+			if fieldPath == skippedPath {
+				fieldPath = ""
+			}
+			fn(boundField{Key: prefix + tag, Path: fieldPath, Field: field, Value: fv})
+			continue
+		}
+		if !isNestedStruct(field.Type) {
+			continue
+		}
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				if !alloc {
+					continue
+				}
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		walkFields(fv, prefix+field.Tag.Get("prefix"), fieldPath, alloc, fn)
+	}
+}
+
+// jsonName returns the name of field in its JSON encoding, or "" if it is skipped.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// skippedPath marks fields inside a struct that is not encoded.
+const skippedPath = "-"
+
+// joinPath appends name to a dotted path.
+func joinPath(path, name string) string {
+	if name == "" || path == skippedPath {
+		return skippedPath
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// fieldSeparator returns the `sep` tag of field, or the default separator.
+func fieldSeparator(field reflect.StructField) string {
+	if sep := field.Tag.Get("sep"); sep != "" {
+		return sep
+	}
+	return defaultSeparator
+}
+
+// isNestedStruct reports whether t is a struct (or pointer to struct) that is bound
+// field by field instead of being converted from a single value.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		if isScalarType(t) {
+			return false
+		}
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isScalarType(t)
+}
@@ -1,34 +1,90 @@
 package env
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// defaultSeparator splits slice items and map pairs when no `sep` tag is given.
+const defaultSeparator = ","
+
+var (
+	convertersMutex sync.RWMutex
+	converters      = map[reflect.Type]func(string) (reflect.Value, error){}
+
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// builtinConverters handles types matched exactly, before pointer, kind and
+// encoding.TextUnmarshaler handling.
+var builtinConverters = map[reflect.Type]func(string) (reflect.Value, error){
+	reflect.TypeFor[json.RawMessage](): func(raw string) (reflect.Value, error) {
+		if !json.Valid([]byte(raw)) {
+			return reflect.Value{}, fmt.Errorf("env: invalid JSON")
+		}
+		return reflect.ValueOf(json.RawMessage(raw)), nil
+	},
+	reflect.TypeFor[time.Time](): func(raw string) (reflect.Value, error) {
+		v, err := parseTime(raw)
+		return reflect.ValueOf(v), err
+	},
+	reflect.TypeFor[time.Duration](): func(raw string) (reflect.Value, error) {
+		v, err := time.ParseDuration(raw)
+		return reflect.ValueOf(v), err
+	},
+	reflect.TypeFor[*time.Location](): func(raw string) (reflect.Value, error) {
+		v, err := time.LoadLocation(raw)
+		return reflect.ValueOf(v), err
+	},
+	reflect.TypeFor[*url.URL](): func(raw string) (reflect.Value, error) {
+		v, err := url.Parse(raw)
+		return reflect.ValueOf(v), err
+	},
+	reflect.TypeFor[net.IP](): func(raw string) (reflect.Value, error) {
+		v := net.ParseIP(raw)
+		if v == nil {
+			return reflect.Value{}, fmt.Errorf("env: invalid IP address %q", raw)
+		}
+		return reflect.ValueOf(v), nil
+	},
+	reflect.TypeFor[*regexp.Regexp](): func(raw string) (reflect.Value, error) {
+		v, err := regexp.Compile(raw)
+		return reflect.ValueOf(v), err
+	},
+	reflect.TypeFor[[]byte](): func(raw string) (reflect.Value, error) {
+		return reflect.ValueOf([]byte(raw)), nil
+	},
+}
+
+// RegisterConverter registers a conversion function for T. Registered converters
+// take precedence over the built-in ones and are used by Get, Lookup and Bind.
+func RegisterConverter[T any](fn func(raw string) (T, error)) {
+	if fn == nil {
+		panic("env: RegisterConverter requires a non-nil function")
+	}
+	convertersMutex.Lock()
+	defer convertersMutex.Unlock()
+	converters[reflect.TypeFor[T]()] = func(raw string) (reflect.Value, error) {
+		v, err := fn(raw)
+		return reflect.ValueOf(&v).Elem(), err
+	}
+}
+
 // convertStringToType converts a string to the specified type T.
 // Supports pointer types: Get[*string], Get[*int32], etc.
 func convertStringToType[T any](raw string) (T, error) {
 	var zero T
 	targetType := reflect.TypeFor[T]()
 
-	if targetType.Kind() == reflect.Ptr {
-		elemType := targetType.Elem()
-		elemVal, err := convertStringToValue(raw, elemType)
-		if err != nil {
-			return zero, err
-		}
-		ptr := reflect.New(elemType)
-		ptr.Elem().Set(elemVal)
-		result, ok := ptr.Interface().(T)
-		if !ok {
-			return zero, fmt.Errorf("env: cannot cast to %v", targetType)
-		}
-		return result, nil
-	}
-
 	v, err := convertStringToValue(raw, targetType)
 	if err != nil {
 		return zero, err
@@ -40,23 +96,36 @@ func convertStringToType[T any](raw string) (T, error) {
 	return result, nil
 }
 
-// convertStringToValue converts a string to a reflect.Value of type t.
+// convertStringToValue converts a string to a reflect.Value of type t, splitting
+// slices and maps on the default separator.
 func convertStringToValue(raw string, t reflect.Type) (reflect.Value, error) {
-	switch t {
-	case reflect.TypeFor[json.RawMessage]():
-		if !json.Valid([]byte(raw)) {
-			return reflect.Value{}, fmt.Errorf("env: invalid JSON")
+	return convertWithSeparator(raw, t, defaultSeparator)
+}
+
+// convertWithSeparator converts a string to a reflect.Value of type t. Slice items
+// and map pairs (k=v) are split on sep.
+func convertWithSeparator(raw string, t reflect.Type, sep string) (reflect.Value, error) {
+	if conv := lookupConverter(t); conv != nil {
+		return conv(raw)
+	}
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		ptr := reflect.New(t)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return reflect.Value{}, err
 		}
-		return reflect.ValueOf(json.RawMessage(raw)), nil
-	case reflect.TypeFor[time.Time]():
-		v, err := parseTime(raw)
-		return reflect.ValueOf(v), err
-	case reflect.TypeFor[time.Duration]():
-		v, err := time.ParseDuration(raw)
-		return reflect.ValueOf(v), err
+		return ptr.Elem(), nil
 	}
 
 	switch t.Kind() {
+	case reflect.Ptr:
+		elemVal, err := convertWithSeparator(raw, t.Elem(), sep)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elemVal)
+		return ptr, nil
 	case reflect.String:
 		return reflect.ValueOf(raw).Convert(t), nil
 	case reflect.Bool:
@@ -71,17 +140,91 @@ func convertStringToValue(raw string, t reflect.Type) (reflect.Value, error) {
 			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(v).Convert(t), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(raw, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(v).Convert(t), nil
 	case reflect.Float32, reflect.Float64:
 		v, err := strconv.ParseFloat(raw, t.Bits())
 		if err != nil {
 			return reflect.Value{}, err
 		}
 		return reflect.ValueOf(v).Convert(t), nil
+	case reflect.Slice:
+		return convertSlice(raw, t, sep)
+	case reflect.Map:
+		return convertMap(raw, t, sep)
 	}
 
 	return reflect.Value{}, fmt.Errorf("env: unsupported type %v", t)
 }
 
+// convertSlice converts "a,b,c" into a slice of t.Elem().
+func convertSlice(raw string, t reflect.Type, sep string) (reflect.Value, error) {
+	parts := splitList(raw, sep)
+	out := reflect.MakeSlice(t, 0, len(parts))
+	for i, part := range parts {
+		v, err := convertWithSeparator(part, t.Elem(), sep)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("env: item %d: %w", i, err)
+		}
+		out = reflect.Append(out, v)
+	}
+	return out, nil
+}
+
+// convertMap converts "k1=v1,k2=v2" into a map of t.Key() to t.Elem().
+func convertMap(raw string, t reflect.Type, sep string) (reflect.Value, error) {
+	parts := splitList(raw, sep)
+	out := reflect.MakeMapWithSize(t, len(parts))
+	for _, part := range parts {
+		rawKey, rawVal, ok := strings.Cut(part, "=")
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("env: invalid map entry %q, expected key=value", part)
+		}
+		k, err := convertWithSeparator(strings.TrimSpace(rawKey), t.Key(), sep)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("env: key %q: %w", rawKey, err)
+		}
+		v, err := convertWithSeparator(strings.TrimSpace(rawVal), t.Elem(), sep)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("env: value of %q: %w", rawKey, err)
+		}
+		out.SetMapIndex(k, v)
+	}
+	return out, nil
+}
+
+// splitList splits raw on sep, trimming items and dropping empty ones.
+func splitList(raw, sep string) []string {
+	var out []string
+	for _, part := range strings.Split(raw, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// lookupConverter returns the registered or built-in converter for t, if any.
+func lookupConverter(t reflect.Type) func(string) (reflect.Value, error) {
+	convertersMutex.RLock()
+	conv := converters[t]
+	convertersMutex.RUnlock()
+	if conv != nil {
+		return conv
+	}
+	return builtinConverters[t]
+}
+
+// isScalarType reports whether t is converted from a single value even though it is
+// a struct, e.g. time.Time, registered types and encoding.TextUnmarshaler implementations.
+func isScalarType(t reflect.Type) bool {
+	return lookupConverter(t) != nil || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
 // parseTime parses a time string.
 func parseTime(raw string) (time.Time, error) {
 	layouts := []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
@@ -0,0 +1,177 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Variable describes an environment variable declared by a config struct.
+type Variable struct {
+	Key         string
+	Type        string
+	Default     string
+	HasDefault  bool
+	Required    bool
+	Secret      bool
+	Description string
+}
+
+// Describe lists the variables declared by T, in field order, from its `env`,
+// `default`, `required`, `secret` and `desc` tags.
+func Describe[T any]() []Variable {
+	return describeType(reflect.TypeFor[T]())
+}
+
+func describeType(t reflect.Type) []Variable {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []Variable
+	visitFields(reflect.New(t).Elem(), "", true, func(f boundField) {
+		def, hasDefault := f.Field.Tag.Lookup("default")
+		out = append(out, Variable{
+			Key:         f.Key,
+			Type:        f.Field.Type.String(),
+			Default:     def,
+			HasDefault:  hasDefault,
+			Required:    f.Field.Tag.Get("required") == "true",
+			Secret:      isSecretField(f.Field),
+			Description: f.Field.Tag.Get("desc"),
+		})
+	})
+	return out
+}
+
+// WriteExample writes a .env.example for T: every variable with its description,
+// type and default. Secrets are written without their default.
+func WriteExample[T any](w io.Writer) error {
+	var sb strings.Builder
+	for i, v := range Describe[T]() {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if v.Description != "" {
+			fmt.Fprintf(&sb, "# %s\n", v.Description)
+		}
+		attrs := []string{v.Type}
+		if v.Required {
+			attrs = append(attrs, "required")
+		}
+		if v.Secret {
+			attrs = append(attrs, "secret")
+		}
+		fmt.Fprintf(&sb, "# (%s)\n", strings.Join(attrs, ", "))
+		value := v.Default
+		if v.Secret {
+			value = ""
+		}
+		fmt.Fprintf(&sb, "%s=%s\n", v.Key, quoteExampleValue(value))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMarkdown writes a markdown table documenting the variables of T.
+func WriteMarkdown[T any](w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("| Variable | Type | Default | Required | Description |\n")
+	sb.WriteString("| -------- | ---- | ------- | -------- | ----------- |\n")
+	for _, v := range Describe[T]() {
+		def := ""
+		if v.HasDefault {
+			def = "`" + v.Default + "`"
+		}
+		required := "no"
+		if v.Required {
+			required = "yes"
+		}
+		desc := v.Description
+		if v.Secret {
+			desc = strings.TrimSpace(desc + " (secret)")
+		}
+		fmt.Fprintf(&sb, "| `%s` | `%s` | %s | %s | %s |\n",
+			v.Key, v.Type, escapeMarkdownCell(def), required, escapeMarkdownCell(desc))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// CheckReport lists the differences between dotenv files and a config struct.
+type CheckReport struct {
+	// Unknown lists keys present in the files but not declared by the struct.
+	Unknown []string
+	// Missing lists required keys without a default that the files do not set.
+	Missing []string
+}
+
+// OK reports whether no difference was found.
+func (r *CheckReport) OK() bool {
+	return len(r.Unknown) == 0 && len(r.Missing) == 0
+}
+
+// Err returns an error describing the differences, or nil if there are none.
+func (r *CheckReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+	var parts []string
+	if len(r.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(r.Missing, ", "))
+	}
+	if len(r.Unknown) > 0 {
+		parts = append(parts, "unknown "+strings.Join(r.Unknown, ", "))
+	}
+	return fmt.Errorf("env: check failed: %s", strings.Join(parts, "; "))
+}
+
+// Check compares the merged contents of dotenv files against the variables declared
+// by T, without changing the environment. A KEY_FILE entry counts as setting KEY.
+func Check[T any](paths ...string) (*CheckReport, error) {
+	e, err := FromFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+	present := map[string]bool{}
+	for _, key := range e.Keys() {
+		present[key] = true
+	}
+
+	report := &CheckReport{}
+	declared := map[string]bool{}
+	for _, v := range Describe[T]() {
+		declared[v.Key] = true
+		declared[v.Key+fileSuffix] = true
+		if v.Required && !v.HasDefault && !present[v.Key] && !present[v.Key+fileSuffix] {
+			report.Missing = append(report.Missing, v.Key)
+		}
+	}
+	for key := range present {
+		if !declared[key] {
+			report.Unknown = append(report.Unknown, key)
+		}
+	}
+	sort.Strings(report.Missing)
+	sort.Strings(report.Unknown)
+	return report, nil
+}
+
+// quoteExampleValue quotes values that would not survive an unquoted dotenv line.
+func quoteExampleValue(value string) string {
+	if value == "" || !strings.ContainsAny(value, " \t#'\"`\\$\n") {
+		return value
+	}
+	if !strings.Contains(value, "'") {
+		return "'" + value + "'"
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`).Replace(value) + `"`
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(value)
+}
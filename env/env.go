@@ -1,67 +1,44 @@
 package env
 
 import (
-	"os"
-	"strings"
-	"sync"
+	"sync/atomic"
 )
 
-var (
-	envMutex sync.RWMutex
-	envStore = map[string]string{}
-)
+var strict atomic.Bool
 
-// Load reads the first valid .env file from the provided paths.
-func Load(filePaths ...string) {
-	for _, path := range filePaths {
-		if path == "" {
-			continue
-		}
-		info, err := os.Stat(path)
-		if err != nil || info.IsDir() {
-			continue
-		}
+// SetStrict enables or disables strict mode. In strict mode Get panics with a
+// *ParseError when a variable is set but cannot be converted, instead of silently
+// returning the default.
+func SetStrict(enabled bool) {
+	strict.Store(enabled)
+}
 
-		if err := loadEnvFile(path); err == nil {
-			return
-		}
-	}
+// Load reads the first existing .env file from the provided paths and returns its
+// syntax or read error, if any. Later paths are only fallbacks for missing files.
+func Load(filePaths ...string) error {
+	return defaultEnv.Load(filePaths...)
 }
 
 // Get retrieves an environment variable and converts it to T.
-// Returns default value if not found or conversion fails.
+// Returns default value if not found or conversion fails (conversion failures panic in strict mode).
 func Get[T any](key string, optionalDefault ...T) T {
-	var zero T
-	val, found := lookupEnv(key)
-	if !found || strings.TrimSpace(val) == "" {
-		if len(optionalDefault) > 0 {
-			return optionalDefault[0]
-		}
-		return zero
-	}
+	return GetFrom(defaultEnv, key, optionalDefault...)
+}
 
-	converted, err := convertStringToType[T](val)
-	if err != nil {
-		if len(optionalDefault) > 0 {
-			return optionalDefault[0]
-		}
-		return zero
-	}
-	return converted
+// Lookup retrieves an environment variable and converts it to T.
+// Returns a *MissingError if the variable is not set or blank, or a *ParseError if
+// the conversion fails.
+func Lookup[T any](key string) (T, error) {
+	return LookupFrom[T](defaultEnv, key)
 }
 
-func lookupEnv(key string) (string, bool) {
-	envMutex.RLock()
-	defer envMutex.RUnlock()
-	if v, ok := envStore[key]; ok {
-		return v, true
-	}
-	return os.LookupEnv(key)
+// MustGet is like Get but panics when the variable cannot be converted, or when it is
+// missing and no default is provided.
+func MustGet[T any](key string, optionalDefault ...T) T {
+	return MustGetFrom(defaultEnv, key, optionalDefault...)
 }
 
-func expandValue(raw string) string {
-	return os.Expand(raw, func(k string) string {
-		v, _ := lookupEnv(k)
-		return v
-	})
+func isMissing(err error) bool {
+	_, ok := err.(*MissingError)
+	return ok
 }
@@ -3,6 +3,7 @@ package env
 import (
 	"io"
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -13,38 +14,70 @@ func (e *errReader) Read(p []byte) (n int, err error) {
 }
 
 func TestEnv_Internal_ScannerError(t *testing.T) {
-	// Covering parseReader error
-	err := parseReader(&errReader{})
+	// Covering loadReader error
+	err := defaultEnv.loadReader(&errReader{}, "")
 	if err == nil {
 		t.Error("Expected scanner error, got nil")
 	}
 
-	// Covering loadEnvFile error return (line 13 in parser.go)
-	err = loadEnvFile("non-existent-file-path-that-really-should-not-exist")
+	// Covering loadFile error return
+	err = defaultEnv.loadFile("non-existent-file-path-that-really-should-not-exist")
 	if err == nil {
-		t.Error("Expected loadEnvFile error, got nil")
+		t.Error("Expected loadFile error, got nil")
 	}
 }
 
 func TestEnv_SystemLookup(t *testing.T) {
 	os.Setenv("SYS_VAR", "VAL")
 	defer os.Unsetenv("SYS_VAR")
-	if v, found := lookupEnv("SYS_VAR"); !found || v != "VAL" {
-		t.Error("lookupEnv fail")
+	if v, found := defaultEnv.Value("SYS_VAR"); !found || v != "VAL" {
+		t.Error("Value fail")
 	}
 }
 
 func TestEnv_ExpandValue(t *testing.T) {
 	os.Setenv("V1", "foo")
-	if v := expandValue("${V1}-bar"); v != "foo-bar" {
+	if v := defaultEnv.expand("${V1}-bar"); v != "foo-bar" {
 		t.Errorf("Expected foo-bar, got %q", v)
 	}
 }
 
 func TestEnv_ExpandValue_NotFound(t *testing.T) {
-	// Variable not found in expansion (covers lookupEnv return "" path)
-	if v := expandValue("${NOT_FOUND_FOR_REAL}"); v != "" {
+	// Variable not found in expansion (covers Value return "" path)
+	if v := defaultEnv.expand("${NOT_FOUND_FOR_REAL}"); v != "" {
 		t.Errorf("Expected empty string for not found var, got %q", v)
 	}
 }
 
+func TestEnv_VariablePath(t *testing.T) {
+	type db struct {
+		Hosts []string `env:"HOSTS" json:"hosts"`
+		Pass  string   `env:"PASS" json:"-"`
+	}
+	type config struct {
+		Port   int `env:"PORT" json:"port"`
+		DB     db  `prefix:"DB_" json:"db"`
+		Hidden db  `prefix:"HIDDEN_" json:"-"`
+	}
+	keys := map[string]string{}
+	visitFields(reflect.ValueOf(&config{}).Elem(), "", false, func(f boundField) {
+		if f.Path != "" {
+			keys[f.Path] = f.Key
+		}
+	})
+	if len(keys) != 2 || keys["port"] != "PORT" || keys["db.hosts"] != "DB_HOSTS" {
+		t.Fatalf("unexpected paths: %v", keys)
+	}
+	for path, want := range map[string]string{
+		"port":        "PORT",
+		"db.hosts":    "DB_HOSTS",
+		"db.hosts[1]": "DB_HOSTS[1]",
+		"db":          "db",
+		"portal":      "portal",
+		"":            "",
+	} {
+		if got := variablePath(path, keys); got != want {
+			t.Errorf("variablePath(%q): expected %q, got %q", path, want, got)
+		}
+	}
+}
@@ -2,12 +2,19 @@ package env_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/leandroluk/gox/env"
+	"github.com/leandroluk/gox/validate"
 )
 
 func TestEnv_Load(t *testing.T) {
@@ -198,7 +205,6 @@ DOUBLE_QUOTE="quoted value"
 MIXED_QUOTES="'mixed'"
 INLINE_COMMENT=val4 # comment
 QUOTE_COMMENT="val # with hash" # real comment
-UNCLOSED="unclosed
 	`
 	os.WriteFile(tmpFile, []byte(content), 0644)
 	env.Load(tmpFile)
@@ -215,13 +221,22 @@ UNCLOSED="unclosed
 		{"MIXED_QUOTES", "'mixed'"},
 		{"INLINE_COMMENT", "val4"},
 		{"QUOTE_COMMENT", "val # with hash"},
-		{"UNCLOSED", "\"unclosed"},
 	}
 	for _, tt := range tests {
 		if v := env.Get[string](tt.key); v != tt.expected {
 			t.Errorf("Key %s: expected %q, got %q", tt.key, tt.expected, v)
 		}
 	}
+
+	// An unclosed quote is reported instead of being kept verbatim.
+	os.WriteFile(tmpFile, []byte(content+"\nUNCLOSED=\"unclosed\n"), 0644)
+	var syntaxErr *env.SyntaxError
+	if err := env.Load(tmpFile); !errors.As(err, &syntaxErr) || syntaxErr.Line != 15 {
+		t.Errorf("expected *env.SyntaxError on line 15, got %v", err)
+	}
+	if _, err := env.Lookup[string]("UNCLOSED"); !errors.Is(err, env.ErrMissing) {
+		t.Errorf("expected UNCLOSED to be unset, got %v", err)
+	}
 }
 
 func TestEnv_Pointers(t *testing.T) {
@@ -278,3 +293,1014 @@ func TestEnv_MoreEdgeCases(t *testing.T) {
 		t.Error("short value fail")
 	}
 }
+
+type bindDB struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" required:"true"`
+}
+
+type bindConfig struct {
+	Name    string        `env:"BIND_NAME" required:"true"`
+	Debug   bool          `env:"BIND_DEBUG"`
+	Timeout time.Duration `env:"BIND_TIMEOUT" default:"5s"`
+	Tags    *string       `env:"BIND_TAGS"`
+	Skip    string        `env:"-"`
+	DB      bindDB        `prefix:"BIND_DB_"`
+	Cache   *bindDB       `prefix:"BIND_CACHE_"`
+	ignored string
+}
+
+func TestEnv_Bind(t *testing.T) {
+	t.Setenv("BIND_NAME", "app")
+	t.Setenv("BIND_DEBUG", "true")
+	t.Setenv("BIND_TAGS", "a,b")
+	t.Setenv("BIND_DB_PORT", "5432")
+	t.Setenv("BIND_CACHE_HOST", "redis")
+	t.Setenv("BIND_CACHE_PORT", "6379")
+
+	cfg, err := env.Bind[bindConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Name != "app" || !cfg.Debug || cfg.Timeout != 5*time.Second {
+		t.Errorf("unexpected top-level fields: %+v", cfg)
+	}
+	if cfg.Tags == nil || *cfg.Tags != "a,b" {
+		t.Errorf("expected *string=a,b, got %v", cfg.Tags)
+	}
+	if cfg.DB.Host != "localhost" || cfg.DB.Port != 5432 {
+		t.Errorf("unexpected nested struct: %+v", cfg.DB)
+	}
+	if cfg.Cache == nil || cfg.Cache.Host != "redis" || cfg.Cache.Port != 6379 {
+		t.Errorf("unexpected nested pointer: %+v", cfg.Cache)
+	}
+}
+
+func TestEnv_Bind_AggregatesErrors(t *testing.T) {
+	t.Setenv("BIND_DB_PORT", "80a")
+	t.Setenv("BIND_CACHE_PORT", "6379")
+
+	_, err := env.Bind[bindConfig]()
+	var bindErr *env.BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("expected *env.BindError, got %v", err)
+	}
+	if len(bindErr.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(bindErr.Errors), err)
+	}
+	for _, want := range []string{"BIND_NAME", `BIND_DB_PORT: cannot parse "80a"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got %v", want, err)
+		}
+	}
+}
+
+func TestEnv_BindTo_InvalidTarget(t *testing.T) {
+	if err := env.BindTo(bindConfig{}); err == nil {
+		t.Error("expected error for non-pointer target")
+	}
+	var nilCfg *bindConfig
+	if err := env.BindTo(nilCfg); err == nil {
+		t.Error("expected error for nil pointer")
+	}
+}
+
+func TestEnv_Lookup(t *testing.T) {
+	t.Setenv("LOOKUP_PORT", "8080")
+	t.Setenv("LOOKUP_BAD", "80a")
+
+	t.Run("Found", func(t *testing.T) {
+		v, err := env.Lookup[int]("LOOKUP_PORT")
+		if err != nil || v != 8080 {
+			t.Errorf("expected 8080, got %d (%v)", v, err)
+		}
+	})
+	t.Run("Missing", func(t *testing.T) {
+		_, err := env.Lookup[int]("LOOKUP_NOT_FOUND")
+		var missing *env.MissingError
+		if !errors.Is(err, env.ErrMissing) || !errors.As(err, &missing) || missing.Key != "LOOKUP_NOT_FOUND" {
+			t.Errorf("expected MissingError, got %v", err)
+		}
+	})
+	t.Run("Parse", func(t *testing.T) {
+		_, err := env.Lookup[int]("LOOKUP_BAD")
+		var parse *env.ParseError
+		if !errors.Is(err, env.ErrParse) || !errors.As(err, &parse) {
+			t.Fatalf("expected ParseError, got %v", err)
+		}
+		if parse.Key != "LOOKUP_BAD" || parse.Raw != "80a" || parse.Err == nil {
+			t.Errorf("unexpected ParseError fields: %+v", parse)
+		}
+		if errors.Is(err, env.ErrMissing) {
+			t.Error("ParseError should not match ErrMissing")
+		}
+	})
+}
+
+func TestEnv_MustGet(t *testing.T) {
+	t.Setenv("MUST_PORT", "8080")
+	t.Setenv("MUST_BAD", "80a")
+
+	if v := env.MustGet[int]("MUST_PORT"); v != 8080 {
+		t.Errorf("expected 8080, got %d", v)
+	}
+	if v := env.MustGet[int]("MUST_NOT_FOUND", 42); v != 42 {
+		t.Errorf("expected default 42, got %d", v)
+	}
+	expectPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		fn()
+	}
+	expectPanic("missing", func() { env.MustGet[int]("MUST_NOT_FOUND") })
+	expectPanic("invalid with default", func() { env.MustGet[int]("MUST_BAD", 42) })
+}
+
+func TestEnv_Strict(t *testing.T) {
+	t.Setenv("STRICT_BAD", "80a")
+	env.SetStrict(true)
+	defer env.SetStrict(false)
+
+	if v := env.Get[int]("STRICT_NOT_FOUND", 42); v != 42 {
+		t.Errorf("strict: missing should still return default, got %d", v)
+	}
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, env.ErrParse) {
+			t.Errorf("strict: expected ParseError panic, got %v", r)
+		}
+	}()
+	env.Get[int]("STRICT_BAD", 42)
+}
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+type semver struct{ major, minor int }
+
+func TestEnv_Converters_Extended(t *testing.T) {
+	t.Run("Uints", func(t *testing.T) {
+		t.Setenv("U8", "255")
+		t.Setenv("U64", "18446744073709551615")
+		t.Setenv("U_NEG", "-1")
+		if env.Get[uint8]("U8") != 255 {
+			t.Error("uint8 fail")
+		}
+		if env.Get[uint64]("U64") != 18446744073709551615 {
+			t.Error("uint64 fail")
+		}
+		if _, err := env.Lookup[uint]("U_NEG"); !errors.Is(err, env.ErrParse) {
+			t.Errorf("expected parse error for negative uint, got %v", err)
+		}
+	})
+	t.Run("Slices", func(t *testing.T) {
+		t.Setenv("HOSTS", "a, b ,c,")
+		t.Setenv("PORTS", "80,443")
+		t.Setenv("PORTS_BAD", "80,x")
+		if v := env.Get[[]string]("HOSTS"); len(v) != 3 || v[0] != "a" || v[1] != "b" || v[2] != "c" {
+			t.Errorf("[]string fail: %v", v)
+		}
+		if v := env.Get[[]int]("PORTS"); len(v) != 2 || v[1] != 443 {
+			t.Errorf("[]int fail: %v", v)
+		}
+		if _, err := env.Lookup[[]int]("PORTS_BAD"); !errors.Is(err, env.ErrParse) {
+			t.Errorf("expected parse error, got %v", err)
+		}
+	})
+	t.Run("Maps", func(t *testing.T) {
+		t.Setenv("LIMITS", "read=10, write=2")
+		t.Setenv("LIMITS_BAD", "read")
+		v := env.Get[map[string]int]("LIMITS")
+		if len(v) != 2 || v["read"] != 10 || v["write"] != 2 {
+			t.Errorf("map fail: %v", v)
+		}
+		if _, err := env.Lookup[map[string]int]("LIMITS_BAD"); !errors.Is(err, env.ErrParse) {
+			t.Errorf("expected parse error, got %v", err)
+		}
+	})
+	t.Run("Bytes", func(t *testing.T) {
+		t.Setenv("BYTES", "raw,value")
+		if v := env.Get[[]byte]("BYTES"); string(v) != "raw,value" {
+			t.Errorf("[]byte fail: %q", v)
+		}
+	})
+	t.Run("URL", func(t *testing.T) {
+		t.Setenv("API_URL", "https://example.com:8443/v1")
+		t.Setenv("API_URL_BAD", "://bad")
+		if v := env.Get[*url.URL]("API_URL"); v == nil || v.Host != "example.com:8443" {
+			t.Errorf("*url.URL fail: %v", v)
+		}
+		if v := env.Get[*url.URL]("API_URL_BAD"); v != nil {
+			t.Errorf("expected nil on error, got %v", v)
+		}
+	})
+	t.Run("IP", func(t *testing.T) {
+		t.Setenv("BIND_IP", "10.0.0.1")
+		t.Setenv("BIND_IP_BAD", "10.0.0")
+		if v := env.Get[net.IP]("BIND_IP"); !v.Equal(net.IPv4(10, 0, 0, 1)) {
+			t.Errorf("net.IP fail: %v", v)
+		}
+		if _, err := env.Lookup[net.IP]("BIND_IP_BAD"); !errors.Is(err, env.ErrParse) {
+			t.Errorf("expected parse error, got %v", err)
+		}
+	})
+	t.Run("Regexp", func(t *testing.T) {
+		t.Setenv("PATTERN", `^v\d+$`)
+		if v := env.Get[*regexp.Regexp]("PATTERN"); v == nil || !v.MatchString("v12") {
+			t.Errorf("*regexp.Regexp fail: %v", v)
+		}
+	})
+	t.Run("Location", func(t *testing.T) {
+		t.Setenv("TZ_NAME", "UTC")
+		if v := env.Get[*time.Location]("TZ_NAME"); v == nil || v.String() != "UTC" {
+			t.Errorf("*time.Location fail: %v", v)
+		}
+	})
+	t.Run("TextUnmarshaler", func(t *testing.T) {
+		t.Setenv("LEVEL", "high")
+		t.Setenv("LEVELS", "low,high")
+		t.Setenv("LEVEL_BAD", "mid")
+		if v := env.Get[level]("LEVEL"); v != 2 {
+			t.Errorf("TextUnmarshaler fail: %v", v)
+		}
+		if v := env.Get[*level]("LEVEL"); v == nil || *v != 2 {
+			t.Errorf("*TextUnmarshaler fail: %v", v)
+		}
+		if v := env.Get[[]level]("LEVELS"); len(v) != 2 || v[0] != 1 {
+			t.Errorf("[]TextUnmarshaler fail: %v", v)
+		}
+		if _, err := env.Lookup[level]("LEVEL_BAD"); !errors.Is(err, env.ErrParse) {
+			t.Errorf("expected parse error, got %v", err)
+		}
+	})
+	t.Run("RegisterConverter", func(t *testing.T) {
+		env.RegisterConverter(func(raw string) (semver, error) {
+			var v semver
+			_, err := fmt.Sscanf(raw, "%d.%d", &v.major, &v.minor)
+			return v, err
+		})
+		t.Setenv("VERSION", "1.4")
+		if v := env.Get[semver]("VERSION"); v.major != 1 || v.minor != 4 {
+			t.Errorf("custom converter fail: %+v", v)
+		}
+	})
+}
+
+func TestEnv_Bind_Collections(t *testing.T) {
+	type config struct {
+		Hosts  []string          `env:"COLL_HOSTS" sep:";"`
+		Limits map[string]int    `env:"COLL_LIMITS"`
+		Level  level             `env:"COLL_LEVEL" default:"low"`
+		URL    *url.URL          `env:"COLL_URL"`
+		Labels map[string]string `env:"COLL_LABELS" sep:"|"`
+	}
+	t.Setenv("COLL_HOSTS", "a,1;b,2")
+	t.Setenv("COLL_LIMITS", "x=1,y=2")
+	t.Setenv("COLL_URL", "http://localhost")
+	t.Setenv("COLL_LABELS", "team=core|tier=1")
+
+	cfg, err := env.Bind[config]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Hosts) != 2 || cfg.Hosts[0] != "a,1" {
+		t.Errorf("sep tag fail: %v", cfg.Hosts)
+	}
+	if cfg.Limits["y"] != 2 || cfg.Level != 1 || cfg.URL == nil || cfg.URL.Host != "localhost" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if cfg.Labels["team"] != "core" || cfg.Labels["tier"] != "1" {
+		t.Errorf("map sep fail: %v", cfg.Labels)
+	}
+}
+
+func TestEnv_Parse_Dotenv(t *testing.T) {
+	t.Setenv("PARSE_OUTER", "outer")
+	content := "\ufeff" + `
+ESCAPES="a\nb\tc\\d\"e\$HOME"
+LITERAL='no \n ${PARSE_OUTER}'
+BACKTICK=` + "`it's \\n`" + `
+MULTI="line1
+line2"
+MULTI_SINGLE='a
+b' # trailing comment
+HASH=#fff
+SPACED= # only a comment
+URL=http://host/#anchor
+REF=$PARSE_OUTER-${PARSE_OUTER}
+LOCAL=${REF}
+EMPTY=
+DEF=${PARSE_MISSING:-fallback}
+DEF_EMPTY=${EMPTY:-fallback}
+DEF_SET_EMPTY=${EMPTY-fallback}
+ALT=${PARSE_OUTER:+alt}
+ALT_MISSING=${PARSE_MISSING:+alt}
+NESTED=${PARSE_MISSING:-${PARSE_OUTER}}
+REQUIRED=${PARSE_OUTER:?must be set}
+DOLLAR=cost $5
+`
+	got, err := env.Parse(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"ESCAPES":       "a\nb\tc\\d\"e$HOME",
+		"LITERAL":       `no \n ${PARSE_OUTER}`,
+		"BACKTICK":      `it's \n`,
+		"MULTI":         "line1\nline2",
+		"MULTI_SINGLE":  "a\nb",
+		"HASH":          "#fff",
+		"SPACED":        "",
+		"URL":           "http://host/#anchor",
+		"REF":           "outer-outer",
+		"LOCAL":         "outer-outer",
+		"DEF":           "fallback",
+		"DEF_EMPTY":     "fallback",
+		"DEF_SET_EMPTY": "",
+		"ALT":           "alt",
+		"ALT_MISSING":   "",
+		"NESTED":        "outer",
+		"REQUIRED":      "outer",
+		"EMPTY":         "",
+		"DOLLAR":        "cost $5",
+	}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("%s: expected %q, got %q", k, w, got[k])
+		}
+	}
+	if _, ok := os.LookupEnv("MULTI"); ok {
+		t.Error("Parse must not change the environment")
+	}
+}
+
+func TestEnv_Parse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		column  int
+	}{
+		{"unterminated double quote", "A=1\nUNCLOSED=\"unclosed\n", 2, 10},
+		{"unterminated single quote", "A='x", 1, 3},
+		{"trailing garbage", "A=\"x\" y", 1, 7},
+		{"unterminated reference", "A=${B", 1, 3},
+		{"invalid reference", "A=x${-B}", 1, 4},
+		{"required missing", "A=\"é ${PARSE_MISSING:?is required}\"", 1, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.Parse(strings.NewReader(tt.content))
+			var syntaxErr *env.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected *env.SyntaxError, got %v", err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("expected %d:%d, got %d:%d (%v)", tt.line, tt.column, syntaxErr.Line, syntaxErr.Column, err)
+			}
+		})
+	}
+}
+
+func TestEnv_Load_SyntaxErrorLeavesStoreUnchanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	bad := filepath.Join(tmpDir, "bad.env")
+	good := filepath.Join(tmpDir, "good.env")
+	os.WriteFile(bad, []byte("SYNTAX_A=1\nSYNTAX_B=\"unclosed\n"), 0644)
+	os.WriteFile(good, []byte("SYNTAX_C=3\n"), 0644)
+	var syntaxErr *env.SyntaxError
+	if err := env.Load(filepath.Join(tmpDir, "missing.env"), bad, good); !errors.As(err, &syntaxErr) || syntaxErr.Line != 2 {
+		t.Fatalf("expected *env.SyntaxError on line 2, got %v", err)
+	}
+	if _, err := env.Lookup[string]("SYNTAX_A"); !errors.Is(err, env.ErrMissing) {
+		t.Errorf("expected SYNTAX_A to be unset, got %v", err)
+	}
+	if _, err := env.Lookup[string]("SYNTAX_C"); !errors.Is(err, env.ErrMissing) {
+		t.Errorf("expected Load not to fall through to the next file, got %v", err)
+	}
+	if err := env.Load(filepath.Join(tmpDir, "missing.env"), good); err != nil || env.Get[string]("SYNTAX_C") != "3" {
+		t.Errorf("expected missing files to be skipped, got %v", err)
+	}
+}
+
+func TestEnv_LoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".env":                  "LAYER_A=base\nLAYER_B=base\nLAYER_C=base\nLAYER_D=base\n",
+		".env.local":            "LAYER_B=local\n",
+		".env.production":       "LAYER_C=production\nLAYER_REF=${LAYER_B}\n",
+		".env.production.local": "LAYER_D=production-local\n",
+		".env.test":             "LAYER_A=test\n",
+	}
+	for name, content := range files {
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+	for _, key := range []string{"LAYER_A", "LAYER_B", "LAYER_C", "LAYER_D", "LAYER_REF"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	report, err := env.LoadProfile("production", env.WithDir(dir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"LAYER_A":   "base",
+		"LAYER_B":   "local",
+		"LAYER_C":   "production",
+		"LAYER_D":   "production-local",
+		"LAYER_REF": "local",
+	}
+	for key, value := range want {
+		if got := env.Get[string](key); got != value {
+			t.Errorf("%s: expected %q, got %q", key, value, got)
+		}
+	}
+	if len(report.Files) != 4 {
+		t.Errorf("expected 4 files, got %v", report.Files)
+	}
+	if src := report.Sources["LAYER_B"]; src != filepath.Join(dir, ".env.local") {
+		t.Errorf("expected LAYER_B from .env.local, got %q", src)
+	}
+	if src := report.Sources["LAYER_A"]; src != filepath.Join(dir, ".env") {
+		t.Errorf("expected LAYER_A from .env, got %q", src)
+	}
+}
+
+func TestEnv_LoadAll_NoOverride(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.env")
+	os.WriteFile(file, []byte("KEEP_PROCESS=file\nKEEP_NEW=file\n"), 0644)
+	t.Setenv("KEEP_PROCESS", "process")
+	t.Setenv("KEEP_NEW", "")
+	env.Default().Unset("KEEP_NEW")
+	t.Cleanup(func() { env.Default().Unset("KEEP_NEW") })
+
+	report, err := env.LoadAll([]string{"", dir, filepath.Join(dir, "missing.env"), file}, env.WithOverride(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := env.Get[string]("KEEP_PROCESS"); got != "process" {
+		t.Errorf("expected process value to be kept, got %q", got)
+	}
+	if got := env.Get[string]("KEEP_NEW"); got != "file" {
+		t.Errorf("expected file value, got %q", got)
+	}
+	if len(report.Kept) != 1 || report.Kept[0] != "KEEP_PROCESS" {
+		t.Errorf("expected KEEP_PROCESS to be reported as kept, got %v", report.Kept)
+	}
+	if _, ok := report.Sources["KEEP_PROCESS"]; ok {
+		t.Error("kept keys should not have a file source")
+	}
+}
+
+func TestEnv_LoadAll_SyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.env")
+	bad := filepath.Join(dir, "bad.env")
+	os.WriteFile(good, []byte("LOADALL_GOOD=1\n"), 0644)
+	os.WriteFile(bad, []byte("LOADALL_BAD='x\n"), 0644)
+
+	_, err := env.LoadAll([]string{good, bad})
+	var syntaxErr *env.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.File != bad {
+		t.Fatalf("expected SyntaxError for %s, got %v", bad, err)
+	}
+	if _, err := env.Lookup[string]("LOADALL_GOOD"); !errors.Is(err, env.ErrMissing) {
+		t.Error("expected no variable to be applied on error")
+	}
+}
+
+func TestEnvironment_Isolated(t *testing.T) {
+	t.Setenv("ISO_PROCESS", "process")
+	e, err := env.FromReader(strings.NewReader("ISO_PORT=8080\nISO_URL=http://localhost:${ISO_PORT}\nISO_REF=${ISO_PROCESS}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v := env.GetFrom[int](e, "ISO_PORT"); v != 8080 {
+		t.Errorf("expected 8080, got %d", v)
+	}
+	if v := env.GetFrom[string](e, "ISO_URL"); v != "http://localhost:8080" {
+		t.Errorf("expected expanded URL, got %q", v)
+	}
+	if v := env.GetFrom[string](e, "ISO_REF"); v != "" {
+		t.Errorf("isolated environment must not read the process, got %q", v)
+	}
+	if _, err := env.LookupFrom[string](e, "ISO_PROCESS"); !errors.Is(err, env.ErrMissing) {
+		t.Errorf("expected ErrMissing, got %v", err)
+	}
+	if _, ok := os.LookupEnv("ISO_PORT"); ok {
+		t.Error("isolated environment must not write the process")
+	}
+	if _, err := env.Lookup[int]("ISO_PORT"); !errors.Is(err, env.ErrMissing) {
+		t.Error("isolated environment must not write the default store")
+	}
+	if keys := e.Keys(); len(keys) != 3 || keys[0] != "ISO_PORT" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+}
+
+func TestEnvironment_Coexist(t *testing.T) {
+	a := env.New(map[string]string{"CO_NAME": "a", "CO_DB_PORT": "1"})
+	b := env.New(map[string]string{"CO_NAME": "b"})
+	b.Set("CO_DB_PORT", "2")
+
+	type config struct {
+		Name string `env:"CO_NAME"`
+		Port int    `env:"CO_DB_PORT" required:"true"`
+	}
+	cfgA, errA := env.BindFrom[config](a)
+	var cfgB config
+	errB := b.BindTo(&cfgB)
+	if errA != nil || errB != nil {
+		t.Fatalf("unexpected errors: %v, %v", errA, errB)
+	}
+	if cfgA.Name != "a" || cfgA.Port != 1 || cfgB.Name != "b" || cfgB.Port != 2 {
+		t.Errorf("unexpected configs: %+v %+v", cfgA, cfgB)
+	}
+	if v := env.MustGetFrom[int](b, "CO_DB_PORT"); v != 2 {
+		t.Errorf("expected 2, got %d", v)
+	}
+}
+
+func TestEnvironment_FromFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	local := filepath.Join(dir, ".env.local")
+	os.WriteFile(base, []byte("FF_A=base\nFF_B=base\n"), 0644)
+	os.WriteFile(local, []byte("FF_B=local\n"), 0644)
+
+	e, err := env.FromFiles(base, local)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.GetFrom[string](e, "FF_A") != "base" || env.GetFrom[string](e, "FF_B") != "local" {
+		t.Errorf("unexpected values: %v", e.Keys())
+	}
+
+	report, err := e.LoadAll([]string{base}, env.WithOverride(false))
+	if err != nil || len(report.Kept) != 2 {
+		t.Errorf("expected existing keys to be kept, got %v (%v)", report, err)
+	}
+
+	os.WriteFile(base, []byte("FF_A='unclosed\n"), 0644)
+	if _, err := env.FromFiles(base); err == nil {
+		t.Error("expected syntax error")
+	}
+	if env.Default() == nil {
+		t.Error("expected default environment")
+	}
+}
+
+func TestEnvironment_Watch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".env")
+	os.WriteFile(file, []byte("W_PORT=8080\nW_NAME=app\nW_OLD=1\n"), 0644)
+
+	e := env.New(nil)
+	var notified [][]string
+	w, err := e.Watch([]string{file}, func(changed []string) {
+		notified = append(notified, changed)
+	}, env.WithPollInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+
+	type config struct {
+		Port int `env:"W_PORT"`
+	}
+	live, err := env.BindLive[config](w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer live.Close()
+	if live.Get().Port != 8080 {
+		t.Errorf("expected 8080, got %d", live.Get().Port)
+	}
+
+	if changed, err := w.Reload(); err != nil || len(changed) != 0 {
+		t.Errorf("expected no change, got %v (%v)", changed, err)
+	}
+
+	os.WriteFile(file, []byte("W_PORT=9090\nW_NAME=app\nW_NEW=1\n"), 0644)
+	changed, err := w.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(changed, ",") != "W_NEW,W_OLD,W_PORT" {
+		t.Errorf("unexpected changed keys %v", changed)
+	}
+	if len(notified) != 1 || strings.Join(notified[0], ",") != "W_NEW,W_OLD,W_PORT" {
+		t.Errorf("unexpected notifications %v", notified)
+	}
+	if _, ok := e.Value("W_OLD"); ok {
+		t.Error("expected removed key to be deleted")
+	}
+	if live.Get().Port != 9090 || live.Err() != nil {
+		t.Errorf("expected re-bound port 9090, got %d (%v)", live.Get().Port, live.Err())
+	}
+
+	os.WriteFile(file, []byte("W_PORT=90a\n"), 0644)
+	if _, err := w.Reload(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if live.Get().Port != 9090 || !errors.Is(live.Err(), env.ErrParse) {
+		t.Errorf("expected previous value and parse error, got %d (%v)", live.Get().Port, live.Err())
+	}
+
+	os.WriteFile(file, []byte("W_PORT='unclosed\n"), 0644)
+	if _, err := w.Reload(); err == nil {
+		t.Error("expected syntax error")
+	}
+	if v, _ := e.Value("W_PORT"); v != "90a" {
+		t.Errorf("expected values to be kept on syntax error, got %q", v)
+	}
+}
+
+func TestEnvironment_Watch_Polling(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".env")
+	os.WriteFile(file, []byte("POLL_A=1\n"), 0644)
+
+	e := env.New(nil)
+	changes := make(chan []string, 16)
+	w, err := e.Watch([]string{file}, func(changed []string) {
+		select {
+		case changes <- changed:
+		default:
+		}
+	}, env.WithPollInterval(5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Stop()
+
+	os.WriteFile(file, []byte("POLL_A=2\n"), 0644)
+	timeout := time.After(2 * time.Second)
+	for env.GetFrom[int](e, "POLL_A") != 2 {
+		select {
+		case changed := <-changes:
+			if len(changed) != 1 || changed[0] != "POLL_A" {
+				t.Errorf("unexpected change %v", changed)
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for change")
+		}
+	}
+}
+
+func TestEnvironment_Watch_NonPositiveInterval(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(file, []byte("A=1\n"), 0644)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		w, err := env.New(nil).Watch([]string{file}, nil, env.WithPollInterval(interval))
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", interval, err)
+		}
+		// Stop waits for the polling goroutine, which must not panic on the interval.
+		w.Stop()
+	}
+}
+
+func TestEnvironment_Watch_InitialError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(file, []byte("A='unclosed\n"), 0644)
+	if _, err := env.New(nil).Watch([]string{file}, nil); err == nil {
+		t.Error("expected initial syntax error")
+	}
+}
+
+func TestEnv_Secrets_FileConvention(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "db_password")
+	os.WriteFile(secretFile, []byte("s3cr3t\n"), 0600)
+
+	e := env.New(map[string]string{
+		"DB_PASSWORD_FILE": secretFile,
+		"DB_USER":          "admin",
+		"DB_USER_FILE":     secretFile,
+		"BROKEN_FILE":      filepath.Join(dir, "missing"),
+	})
+	if v := env.GetFrom[string](e, "DB_PASSWORD"); v != "s3cr3t" {
+		t.Errorf("expected secret from file, got %q", v)
+	}
+	if v := env.GetFrom[string](e, "DB_USER"); v != "admin" {
+		t.Errorf("expected variable to take precedence over _FILE, got %q", v)
+	}
+	_, err := env.LookupFrom[string](e, "BROKEN")
+	var secretErr *env.SecretError
+	if !errors.As(err, &secretErr) || secretErr.Key != "BROKEN" {
+		t.Errorf("expected SecretError, got %v", err)
+	}
+	if _, ok := e.Value("BROKEN"); ok {
+		t.Error("expected unreadable secret to be reported as not found by Value")
+	}
+}
+
+func TestEnv_Secrets_Providers(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "api-key"), []byte("key-123\r\n"), 0600)
+	env.RegisterSecretProvider("dirsecret", env.DirSecretProvider{Dir: dir})
+	env.RegisterSecretProvider("static", env.SecretProviderFunc(func(name string) (string, error) {
+		return "static-" + name, nil
+	}))
+
+	e := env.New(map[string]string{
+		"API_KEY":  "dirsecret://api-key",
+		"TOKEN":    "static://token",
+		"ESCAPE":   "dirsecret://../etc/passwd",
+		"MISSING":  "dirsecret://missing",
+		"HOMEPAGE": "https://example.com",
+	})
+	if v := env.GetFrom[string](e, "API_KEY"); v != "key-123" {
+		t.Errorf("expected key-123, got %q", v)
+	}
+	if v := env.GetFrom[string](e, "TOKEN"); v != "static-token" {
+		t.Errorf("expected static-token, got %q", v)
+	}
+	if v := env.GetFrom[string](e, "HOMEPAGE"); v != "https://example.com" {
+		t.Errorf("unregistered schemes must be kept, got %q", v)
+	}
+	for _, key := range []string{"ESCAPE", "MISSING"} {
+		if _, err := env.LookupFrom[string](e, key); err == nil {
+			t.Errorf("%s: expected error", key)
+		}
+	}
+}
+
+func TestEnv_Secrets_Dump(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "pw")
+	os.WriteFile(secretFile, []byte("hunter2"), 0600)
+
+	type db struct {
+		Host     string `env:"HOST"`
+		Password string `env:"PASSWORD"`
+	}
+	type config struct {
+		Name    string            `env:"DUMP_NAME"`
+		Token   string            `env:"DUMP_TOKEN" secret:"true"`
+		APIKey  env.Secret        `env:"DUMP_API_KEY"`
+		Hosts   []string          `env:"DUMP_HOSTS" sep:";"`
+		Labels  map[string]string `env:"DUMP_LABELS"`
+		Timeout time.Duration     `env:"DUMP_TIMEOUT"`
+		URL     *url.URL          `env:"DUMP_URL"`
+		Port    *int              `env:"DUMP_PORT"`
+		DB      db                `prefix:"DUMP_DB_"`
+		Cache   *db               `prefix:"DUMP_CACHE_"`
+	}
+	e := env.New(map[string]string{
+		"DUMP_NAME":             "app",
+		"DUMP_TOKEN":            "tok",
+		"DUMP_API_KEY":          "key",
+		"DUMP_HOSTS":            "a;b",
+		"DUMP_LABELS":           "b=2,a=1",
+		"DUMP_TIMEOUT":          "5s",
+		"DUMP_URL":              "http://localhost",
+		"DUMP_DB_HOST":          "db",
+		"DUMP_DB_PASSWORD_FILE": secretFile,
+	})
+	cfg, err := env.BindFrom[config](e)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.APIKey.Reveal() != "key" || fmt.Sprint(cfg.APIKey) != "[REDACTED]" {
+		t.Errorf("unexpected Secret behavior: %q", fmt.Sprint(cfg.APIKey))
+	}
+	if b, _ := json.Marshal(cfg.APIKey); string(b) != `"[REDACTED]"` {
+		t.Errorf("expected redacted JSON, got %s", b)
+	}
+	if cfg.DB.Password != "hunter2" {
+		t.Errorf("expected password from file, got %q", cfg.DB.Password)
+	}
+
+	dump := e.Dump(&cfg)
+	want := map[string]string{
+		"DUMP_NAME":           "app",
+		"DUMP_TOKEN":          "[REDACTED]",
+		"DUMP_API_KEY":        "[REDACTED]",
+		"DUMP_HOSTS":          "a;b",
+		"DUMP_LABELS":         "a=1,b=2",
+		"DUMP_TIMEOUT":        "5s",
+		"DUMP_URL":            "http://localhost",
+		"DUMP_PORT":           "",
+		"DUMP_DB_HOST":        "db",
+		"DUMP_DB_PASSWORD":    "[REDACTED]",
+		"DUMP_CACHE_HOST":     "",
+		"DUMP_CACHE_PASSWORD": "",
+	}
+	if len(dump) != len(want) {
+		t.Errorf("expected %d entries, got %v", len(want), dump)
+	}
+	for k, v := range want {
+		if dump[k] != v {
+			t.Errorf("%s: expected %q, got %q", k, v, dump[k])
+		}
+	}
+	cfg.Cache = nil
+	if _, ok := e.Dump(cfg)["DUMP_CACHE_HOST"]; ok {
+		t.Error("expected nil nested pointers to be skipped")
+	}
+	if len(env.Dump(nil)) != 0 || len(env.Dump("x")) != 0 {
+		t.Error("expected empty dump for non-struct values")
+	}
+}
+
+type docsConfig struct {
+	Host    string        `env:"DOCS_HOST" default:"localhost" desc:"Address to listen on"`
+	Port    int           `env:"DOCS_PORT" required:"true" desc:"Port | public"`
+	Token   string        `env:"DOCS_TOKEN" secret:"true" default:"dev" desc:"API token"`
+	Timeout time.Duration `env:"DOCS_TIMEOUT" default:"5s"`
+	Greet   string        `env:"DOCS_GREET" default:"hello world"`
+	DB      struct {
+		URL string `env:"URL" required:"true"`
+	} `prefix:"DOCS_DB_"`
+}
+
+func TestEnv_Describe(t *testing.T) {
+	vars := env.Describe[docsConfig]()
+	keys := make([]string, len(vars))
+	for i, v := range vars {
+		keys[i] = v.Key
+	}
+	if got := strings.Join(keys, ","); got != "DOCS_HOST,DOCS_PORT,DOCS_TOKEN,DOCS_TIMEOUT,DOCS_GREET,DOCS_DB_URL" {
+		t.Fatalf("unexpected keys: %s", got)
+	}
+	if v := vars[0]; v.Type != "string" || v.Default != "localhost" || !v.HasDefault || v.Required || v.Description != "Address to listen on" {
+		t.Errorf("unexpected DOCS_HOST: %+v", v)
+	}
+	if v := vars[1]; v.Type != "int" || v.HasDefault || !v.Required {
+		t.Errorf("unexpected DOCS_PORT: %+v", v)
+	}
+	if !vars[2].Secret || vars[3].Type != "time.Duration" {
+		t.Errorf("unexpected variables: %+v", vars)
+	}
+	if env.Describe[int]() != nil {
+		t.Error("expected nil for non-struct type")
+	}
+}
+
+func TestEnv_WriteExample(t *testing.T) {
+	var sb strings.Builder
+	if err := env.WriteExample[docsConfig](&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `# Address to listen on
+# (string)
+DOCS_HOST=localhost
+
+# Port | public
+# (int, required)
+DOCS_PORT=
+
+# API token
+# (string, secret)
+DOCS_TOKEN=
+
+# (time.Duration)
+DOCS_TIMEOUT=5s
+
+# (string)
+DOCS_GREET='hello world'
+
+# (string, required)
+DOCS_DB_URL=
+`
+	if sb.String() != want {
+		t.Errorf("unexpected example:\n%s", sb.String())
+	}
+
+	// The example is valid dotenv.
+	values, err := env.Parse(strings.NewReader(sb.String()))
+	if err != nil || values["DOCS_GREET"] != "hello world" {
+		t.Errorf("expected parseable example, got %v, %v", values, err)
+	}
+}
+
+func TestEnv_WriteMarkdown(t *testing.T) {
+	var sb strings.Builder
+	if err := env.WriteMarkdown[docsConfig](&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("expected 8 lines, got %d:\n%s", len(lines), sb.String())
+	}
+	for i, want := range map[int]string{
+		2: "| `DOCS_HOST` | `string` | `localhost` | no | Address to listen on |",
+		3: "| `DOCS_PORT` | `int` |  | yes | Port \\| public |",
+		4: "| `DOCS_TOKEN` | `string` | `dev` | no | API token (secret) |",
+	} {
+		if lines[i] != want {
+			t.Errorf("line %d: expected %q, got %q", i, want, lines[i])
+		}
+	}
+}
+
+func TestEnv_Check(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	os.WriteFile(path, []byte("DOCS_HOST=0.0.0.0\nDOCS_DB_URL_FILE=/run/secrets/db\nDOCS_LEGACY=1\n"), 0644)
+
+	report, err := env.Check[docsConfig](path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.OK() {
+		t.Fatal("expected differences")
+	}
+	if strings.Join(report.Missing, ",") != "DOCS_PORT" {
+		t.Errorf("unexpected missing keys: %v", report.Missing)
+	}
+	if strings.Join(report.Unknown, ",") != "DOCS_LEGACY" {
+		t.Errorf("unexpected unknown keys: %v", report.Unknown)
+	}
+	if err := report.Err(); err == nil || err.Error() != "env: check failed: missing DOCS_PORT; unknown DOCS_LEGACY" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	os.WriteFile(path, []byte("DOCS_PORT=80\nDOCS_DB_URL=postgres://db\n"), 0644)
+	report, err = env.Check[docsConfig](path)
+	if err != nil || !report.OK() || report.Err() != nil {
+		t.Errorf("expected clean report, got %+v, %v", report, err)
+	}
+
+	os.WriteFile(path, []byte("BROKEN=\"unterminated\n"), 0644)
+	if _, err := env.Check[docsConfig](path); err == nil {
+		t.Error("expected syntax error")
+	}
+}
+
+type validatedConfig struct {
+	Port  int      `env:"PORT" json:"port"`
+	URL   string   `env:"URL"`
+	Hosts []string `env:"HOSTS" json:"hosts"`
+	Mode  string   `env:"MODE" json:"mode"`
+}
+
+func TestEnv_Bind_Validate(t *testing.T) {
+	validate.Register(validate.Object(func(c *validatedConfig, s *validate.ObjectSchema[validatedConfig]) {
+		s.Field(&c.Port).Number().Integer().Min(1).Max(65535)
+		s.Field(&c.URL).Text().Required().URL()
+		s.Field(&c.Hosts).Array(validate.Text().Min(3))
+		s.Field(&c.Mode).Text().OneOf("dev", "prod")
+	}))
+	t.Cleanup(validate.ResetRegistry)
+
+	e := env.New(map[string]string{
+		"PORT":  "70000",
+		"URL":   "not a url",
+		"HOSTS": "db1,x",
+		"MODE":  "test",
+	})
+	_, err := env.BindFrom[validatedConfig](e)
+	var bindErr *env.BindError
+	if !errors.As(err, &bindErr) {
+		t.Fatalf("expected *BindError, got %v", err)
+	}
+	if !errors.Is(err, env.ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+	var paths []string
+	for _, e := range bindErr.Errors {
+		var invalid *env.InvalidError
+		if !errors.As(e, &invalid) {
+			t.Fatalf("expected *InvalidError, got %T", e)
+		}
+		paths = append(paths, invalid.Path)
+	}
+	if got := strings.Join(paths, ","); got != "PORT,URL,HOSTS[1],MODE" {
+		t.Errorf("unexpected paths: %s\n%v", got, err)
+	}
+	if !strings.Contains(err.Error(), "\n  env: MODE: not allowed (text.oneof)") {
+		t.Errorf("unexpected message: %v", err)
+	}
+
+	// Valid values pass and binding errors skip validation.
+	e = env.New(map[string]string{"PORT": "8080", "URL": "https://example.com", "HOSTS": "db1,db2", "MODE": "dev"})
+	if cfg, err := env.BindFrom[validatedConfig](e); err != nil || cfg.Port != 8080 || len(cfg.Hosts) != 2 {
+		t.Errorf("unexpected result: %+v, %v", cfg, err)
+	}
+	e = env.New(map[string]string{"PORT": "abc"})
+	_, err = env.BindFrom[validatedConfig](e)
+	if !errors.Is(err, env.ErrParse) || errors.Is(err, env.ErrInvalid) {
+		t.Errorf("expected only a parse error, got %v", err)
+	}
+}
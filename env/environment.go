@@ -0,0 +1,205 @@
+package env
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Environment is a set of variables with its own store. The default instance, used by
+// the package-level functions, falls back to the process environment and writes loaded
+// variables to it. Instances created with New, FromReader or FromFiles are isolated:
+// they never read or change the process environment.
+type Environment struct {
+	mu     sync.RWMutex
+	store  map[string]string
+	system bool
+}
+
+var defaultEnv = &Environment{store: map[string]string{}, system: true}
+
+// Default returns the Environment used by the package-level functions.
+func Default() *Environment {
+	return defaultEnv
+}
+
+// New returns an isolated Environment holding a copy of values.
+func New(values map[string]string) *Environment {
+	e := &Environment{store: make(map[string]string, len(values))}
+	for key, value := range values {
+		e.store[key] = value
+	}
+	return e
+}
+
+// FromReader returns an isolated Environment with the variables parsed from r.
+func FromReader(r io.Reader) (*Environment, error) {
+	e := New(nil)
+	if err := e.LoadReader(r); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// FromFiles returns an isolated Environment with the variables of the existing files
+// in paths, merged as in LoadAll.
+func FromFiles(paths ...string) (*Environment, error) {
+	e := New(nil)
+	if _, err := e.LoadAll(paths); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Value returns the value of key. When key is unset, the file named by KEY_FILE is
+// read instead; values referencing a registered secret provider are resolved. Secrets
+// that cannot be read are reported as not found; LookupFrom and Bind report the error.
+func (e *Environment) Value(key string) (string, bool) {
+	v, found, err := e.resolve(key)
+	return v, found && err == nil
+}
+
+// rawValue returns the value of key as stored, without secret resolution.
+func (e *Environment) rawValue(key string) (string, bool) {
+	e.mu.RLock()
+	v, ok := e.store[key]
+	e.mu.RUnlock()
+	if ok || !e.system {
+		return v, ok
+	}
+	return os.LookupEnv(key)
+}
+
+// Set stores a raw value for key.
+func (e *Environment) Set(key, value string) {
+	e.apply(map[string]string{key: value})
+}
+
+// Unset removes key from the store and, for the default instance, from the process
+// environment.
+func (e *Environment) Unset(key string) {
+	e.swap(nil, []string{key})
+}
+
+// Keys returns the sorted keys held by the Environment store. For the default
+// instance, variables only present in the process environment are not included.
+func (e *Environment) Keys() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	keys := make([]string, 0, len(e.store))
+	for key := range e.store {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Load reads the first existing .env file from the provided paths. Missing files and
+// directories are skipped; the first existing file is the only one read, and its
+// syntax or read error is returned with the environment left unchanged.
+func (e *Environment) Load(filePaths ...string) error {
+	for _, path := range filePaths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		return e.loadFile(path)
+	}
+	return nil
+}
+
+// LoadReader parses dotenv content from r and stores its variables.
+func (e *Environment) LoadReader(r io.Reader) error {
+	return e.loadReader(r, "")
+}
+
+// BindTo populates the struct pointed to by ptr. See Bind for the supported tags.
+func (e *Environment) BindTo(ptr any) error {
+	return bindTo(e, ptr)
+}
+
+// apply writes values to the store and, for the default instance, to the process
+// environment.
+func (e *Environment) apply(values map[string]string) {
+	e.mu.Lock()
+	for key, value := range values {
+		e.store[key] = value
+	}
+	e.mu.Unlock()
+
+	if e.system {
+		for key, value := range values {
+			_ = os.Setenv(key, value)
+		}
+	}
+}
+
+// expand resolves variable references in raw using the dotenv expansion rules.
+// Invalid references are kept verbatim.
+func (e *Environment) expand(raw string) string {
+	p := &dotenvParser{src: raw, lookup: e.Value}
+	v, err := p.expand(raw, 0, false)
+	if err != nil {
+		return raw
+	}
+	return v
+}
+
+// GetFrom is Get for a specific Environment.
+func GetFrom[T any](e *Environment, key string, optionalDefault ...T) T {
+	var zero T
+	val, err := LookupFrom[T](e, key)
+	if err == nil {
+		return val
+	}
+	if strict.Load() && !isMissing(err) {
+		panic(err)
+	}
+	if len(optionalDefault) > 0 {
+		return optionalDefault[0]
+	}
+	return zero
+}
+
+// LookupFrom is Lookup for a specific Environment.
+func LookupFrom[T any](e *Environment, key string) (T, error) {
+	var zero T
+	val, found, err := e.resolve(key)
+	if err != nil {
+		return zero, err
+	}
+	if !found || strings.TrimSpace(val) == "" {
+		return zero, &MissingError{Key: key}
+	}
+
+	converted, err := convertStringToType[T](val)
+	if err != nil {
+		return zero, &ParseError{Key: key, Raw: val, Type: reflect.TypeFor[T](), Err: err}
+	}
+	return converted, nil
+}
+
+// MustGetFrom is MustGet for a specific Environment.
+func MustGetFrom[T any](e *Environment, key string, optionalDefault ...T) T {
+	val, err := LookupFrom[T](e, key)
+	if err == nil {
+		return val
+	}
+	if isMissing(err) && len(optionalDefault) > 0 {
+		return optionalDefault[0]
+	}
+	panic(err)
+}
+
+// BindFrom is Bind for a specific Environment.
+func BindFrom[T any](e *Environment) (T, error) {
+	var out T
+	err := bindTo(e, &out)
+	return out, err
+}
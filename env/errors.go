@@ -0,0 +1,85 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrMissing matches every *MissingError via errors.Is.
+	ErrMissing = errors.New("env: variable is not set")
+	// ErrParse matches every *ParseError via errors.Is.
+	ErrParse = errors.New("env: variable cannot be parsed")
+	// ErrInvalid matches every *InvalidError via errors.Is.
+	ErrInvalid = errors.New("env: variable is invalid")
+)
+
+// MissingError reports a variable that is not set or blank.
+type MissingError struct {
+	Key string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("env: %s is not set", e.Key)
+}
+
+func (e *MissingError) Is(target error) bool {
+	return target == ErrMissing
+}
+
+// ParseError reports a variable whose raw value cannot be converted to Type.
+type ParseError struct {
+	Key  string
+	Raw  string
+	Type reflect.Type
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("env: %s: cannot parse %q as %v: %v", e.Key, e.Raw, e.Type, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// InvalidError reports a bound value rejected by the validate schema registered for
+// the config struct. Path is the variable name, followed by the index or key of the
+// item for collections (e.g. HOSTS[1]), and is empty for struct-level rules.
+type InvalidError struct {
+	Path    string
+	Code    string
+	Message string
+}
+
+func (e *InvalidError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("env: %s (%s)", e.Message, e.Code)
+	}
+	return fmt.Sprintf("env: %s: %s (%s)", e.Path, e.Message, e.Code)
+}
+
+func (e *InvalidError) Is(target error) bool {
+	return target == ErrInvalid
+}
+
+// SyntaxError reports invalid dotenv content with its position. File is empty when
+// the content was not read from a file.
+type SyntaxError struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("env: line %d, column %d: %s", e.Line, e.Column, e.Msg)
+	}
+	return fmt.Sprintf("env: %s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
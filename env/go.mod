@@ -1,3 +1,7 @@
 module github.com/leandroluk/gox/env
 
 go 1.25
+
+// env uses validate.Lookup, which ships with the same release; tag both
+// modules together (see _tools/tag).
+require github.com/leandroluk/gox/validate v0.1.0
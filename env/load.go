@@ -0,0 +1,188 @@
+package env
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// LoadOptions configures LoadAll, LoadProfile and Watch.
+type LoadOptions struct {
+	// Override replaces variables already set in the process environment. Default: true.
+	Override bool
+	// Dir is the directory used by LoadProfile to locate its files. Default: ".".
+	Dir string
+	// PollInterval is how often Watch checks its files; non-positive values use the
+	// default. Default: 1s.
+	PollInterval time.Duration
+	// OnError receives Watch reload errors; the previous values are kept. Default: ignored.
+	OnError func(error)
+}
+
+type LoadOption func(*LoadOptions)
+
+// WithOverride controls whether loaded files replace variables already set in the
+// process environment.
+func WithOverride(value bool) LoadOption {
+	return func(o *LoadOptions) { o.Override = value }
+}
+
+// WithDir sets the directory used by LoadProfile.
+func WithDir(dir string) LoadOption {
+	return func(o *LoadOptions) { o.Dir = dir }
+}
+
+// WithPollInterval sets how often Watch checks its files. Non-positive values keep
+// the default of 1s.
+func WithPollInterval(d time.Duration) LoadOption {
+	return func(o *LoadOptions) { o.PollInterval = d }
+}
+
+// WithErrorHandler sets the function receiving Watch reload errors.
+func WithErrorHandler(fn func(error)) LoadOption {
+	return func(o *LoadOptions) { o.OnError = fn }
+}
+
+func applyLoadOptions(optionList []LoadOption) LoadOptions {
+	options := LoadOptions{Override: true, Dir: ".", PollInterval: time.Second}
+	for _, option := range optionList {
+		if option != nil {
+			option(&options)
+		}
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = time.Second
+	}
+	return options
+}
+
+// LoadReport describes the outcome of LoadAll.
+type LoadReport struct {
+	// Files lists the files that were read, in precedence order (lowest first).
+	Files []string
+	// Sources maps each applied key to the file that provided its final value.
+	Sources map[string]string
+	// Kept lists the keys found in files but kept from the process environment
+	// because Override was disabled.
+	Kept []string
+}
+
+// LoadAll reads every existing file in paths and merges them, later files taking
+// precedence over earlier ones. Missing files and directories are skipped. Files are
+// all parsed before the environment changes, so a syntax error leaves it untouched.
+func LoadAll(paths []string, opts ...LoadOption) (*LoadReport, error) {
+	return defaultEnv.LoadAll(paths, opts...)
+}
+
+// LoadAll is the package-level LoadAll for a specific Environment. With Override
+// disabled, keys already held by the Environment are kept.
+func (e *Environment) LoadAll(paths []string, opts ...LoadOption) (*LoadReport, error) {
+	merged, report, err := e.collect(paths, applyLoadOptions(opts), readEnvFile, nil)
+	if err != nil {
+		return nil, err
+	}
+	e.apply(merged)
+	return report, nil
+}
+
+// collect parses and merges paths without changing the Environment. Keys in previous
+// are values this Environment got from an earlier collect of the same files: they are
+// neither visible to expansions nor protected from overriding.
+func (e *Environment) collect(
+	paths []string,
+	options LoadOptions,
+	read func(string) ([]byte, bool, error),
+	previous map[string]string,
+) (map[string]string, *LoadReport, error) {
+	report := &LoadReport{Sources: map[string]string{}}
+	merged := map[string]string{}
+	kept := map[string]bool{}
+
+	existing := func(key string) (string, bool) {
+		if _, stale := previous[key]; stale {
+			return "", false
+		}
+		return e.Value(key)
+	}
+	lookup := func(key string) (string, bool) {
+		if v, ok := merged[key]; ok {
+			return v, true
+		}
+		return existing(key)
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, found, err := read(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			continue
+		}
+		values, err := parseDotenv(string(data), path, lookup)
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Files = append(report.Files, path)
+		for key, value := range values {
+			if !options.Override {
+				if _, exists := existing(key); exists {
+					if !kept[key] {
+						kept[key] = true
+						report.Kept = append(report.Kept, key)
+					}
+					continue
+				}
+			}
+			merged[key] = value
+			report.Sources[key] = path
+		}
+	}
+
+	sort.Strings(report.Kept)
+	return merged, report, nil
+}
+
+// LoadProfile loads .env, .env.local, .env.{profile} and .env.{profile}.local from
+// the configured directory, in increasing order of precedence. An empty profile loads
+// only .env and .env.local.
+func LoadProfile(profile string, opts ...LoadOption) (*LoadReport, error) {
+	return defaultEnv.LoadProfile(profile, opts...)
+}
+
+// LoadProfile is the package-level LoadProfile for a specific Environment.
+func (e *Environment) LoadProfile(profile string, opts ...LoadOption) (*LoadReport, error) {
+	dir := applyLoadOptions(opts).Dir
+	names := []string{".env", ".env.local"}
+	if profile != "" {
+		names = append(names, ".env."+profile, ".env."+profile+".local")
+	}
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, name)
+	}
+	return e.LoadAll(paths, opts...)
+}
+
+// readEnvFile reads path, reporting missing files and directories as not found.
+func readEnvFile(path string) ([]byte, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || isDir(path) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
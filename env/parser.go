@@ -1,94 +1,353 @@
 package env
 
 import (
-	"bufio"
 	"io"
 	"os"
 	"strings"
+	"unicode/utf8"
 )
 
-// loadEnvFile reads a file line by line and populates the store.
-func loadEnvFile(filePath string) error {
+// loadFile reads a file and populates the store.
+func (e *Environment) loadFile(filePath string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
-	return parseReader(file)
+	return e.loadReader(file, filePath)
 }
 
-func parseReader(r io.Reader) error {
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		key, value, ok := parseEnvLine(scanner.Text())
-		if !ok {
-			continue
-		}
+// loadReader parses the whole input before touching the store, so a syntax error
+// leaves it unchanged.
+func (e *Environment) loadReader(r io.Reader, name string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	values, err := parseDotenv(string(data), name, e.Value)
+	if err != nil {
+		return err
+	}
+	e.apply(values)
+	return nil
+}
 
-		expandedValue := expandValue(value)
+// Parse reads dotenv content from r and returns its variables without changing the
+// environment. References to variables not defined in r are resolved from the
+// current environment.
+func Parse(r io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseDotenv(string(data), "", defaultEnv.Value)
+}
 
-		envMutex.Lock()
-		envStore[key] = expandedValue
-		envMutex.Unlock()
+// dotenvParser implements the dotenv format used by docker compose:
+//
+//   - blank lines and lines starting with # are ignored; lines without = are skipped
+//   - an optional "export " prefix before the key
+//   - unquoted values are trimmed; # preceded by whitespace starts a comment
+//   - 'single' and `backtick` quoted values are literal and may span lines
+//   - "double" quoted values may span lines and support \n \r \t \\ \" \$ escapes
+//   - unquoted and double quoted values expand $VAR, ${VAR}, ${VAR:-default},
+//     ${VAR-default}, ${VAR:?error}, ${VAR?error}, ${VAR:+alt} and ${VAR+alt}
+type dotenvParser struct {
+	src    string
+	pos    int
+	name   string
+	values map[string]string
+	lookup func(string) (string, bool)
+}
 
-		_ = os.Setenv(key, expandedValue)
+func parseDotenv(src, name string, lookup func(string) (string, bool)) (map[string]string, error) {
+	p := &dotenvParser{
+		src:    strings.TrimPrefix(src, "\ufeff"),
+		name:   name,
+		values: map[string]string{},
+		lookup: lookup,
 	}
-	return scanner.Err()
+	for p.pos < len(p.src) {
+		if err := p.parseStatement(); err != nil {
+			return nil, err
+		}
+	}
+	return p.values, nil
 }
 
-// parseEnvLine extracts key and value, handling spaces, quotes and comments.
-func parseEnvLine(rawLine string) (string, string, bool) {
-	line := strings.TrimSpace(rawLine)
-	if line == "" || strings.HasPrefix(line, "#") {
-		return "", "", false
+func (p *dotenvParser) parseStatement() error {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return nil
+	}
+	if p.src[p.pos] == '\n' || p.src[p.pos] == '#' {
+		p.skipLine()
+		return nil
+	}
+
+	lineEnd := p.lineEnd()
+	eq := strings.IndexByte(p.src[p.pos:lineEnd], '=')
+	if eq < 0 {
+		p.skipLine()
+		return nil
+	}
+	key := strings.TrimSpace(p.src[p.pos : p.pos+eq])
+	if rest, ok := strings.CutPrefix(key, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+		key = strings.TrimSpace(rest)
+	}
+	p.pos += eq + 1
+	if key == "" {
+		p.skipLine()
+		return nil
 	}
 
-	if strings.HasPrefix(line, "export ") {
-		line = strings.TrimSpace(line[len("export "):])
+	value, err := p.parseValue()
+	if err != nil {
+		return err
 	}
+	p.values[key] = value
+	return nil
+}
 
-	before, after, ok := strings.Cut(line, "=")
-	if !ok {
-		return "", "", false
+func (p *dotenvParser) parseValue() (string, error) {
+	start := p.pos
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return "", nil
 	}
 
-	key := strings.TrimSpace(before)
-	value := strings.TrimSpace(after)
-	value = stripInlineComments(value)
+	switch quote := p.src[p.pos]; quote {
+	case '\'', '`', '"':
+		open := p.pos
+		end := p.findClosingQuote(quote)
+		if end < 0 {
+			return "", p.errorAt(open, "unterminated quoted value")
+		}
+		raw := p.src[open+1 : end]
+		p.pos = end + 1
+		if err := p.expectLineEnd(); err != nil {
+			return "", err
+		}
+		if quote != '"' {
+			return raw, nil
+		}
+		return p.expand(raw, open+1, true)
+	}
 
-	// Remove surrounding quotes
-	if len(value) >= 2 {
-		first, last := value[0], value[len(value)-1]
-		if (first == '"' && last == '"') || (first == '\'' && last == '\'') {
-			value = value[1 : len(value)-1]
+	lineEnd := p.lineEnd()
+	raw := p.src[p.pos:lineEnd]
+	if p.pos > start && strings.HasPrefix(raw, "#") {
+		raw = ""
+	}
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			raw = raw[:i]
+			break
 		}
 	}
+	offset := p.pos
+	p.pos = lineEnd
+	return p.expand(strings.TrimSpace(raw), offset, false)
+}
 
-	if key == "" {
-		return "", "", false
+// findClosingQuote returns the index of the quote closing the one at p.pos, or -1.
+func (p *dotenvParser) findClosingQuote(quote byte) int {
+	for i := p.pos + 1; i < len(p.src); i++ {
+		switch p.src[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i
+		}
 	}
-	return key, value, true
+	return -1
 }
 
-// stripInlineComments removes inline comments from a string.
-func stripInlineComments(value string) string {
-	inQuotes := false
-	var quoteChar rune
+// expectLineEnd accepts only whitespace or a comment after a quoted value.
+func (p *dotenvParser) expectLineEnd() error {
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '#' {
+		return p.errorAt(p.pos, "unexpected character after quoted value")
+	}
+	p.skipLine()
+	return nil
+}
 
-	for i, char := range value {
-		if char == '"' || char == '\'' {
-			if !inQuotes {
-				inQuotes, quoteChar = true, char
-			} else if quoteChar == char {
-				inQuotes = false
+// expand resolves variable references in raw, whose first byte is at offset in the
+// source. When escapes is set, backslash sequences are processed too.
+func (p *dotenvParser) expand(raw string, offset int, escapes bool) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case escapes && c == '\\' && i+1 < len(raw):
+			i++
+			switch raw[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '$':
+				sb.WriteByte(raw[i])
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(raw[i])
 			}
+		case escapes && c == '\r' && i+1 < len(raw) && raw[i+1] == '\n':
+			// normalize CRLF inside multiline values
+		case c == '$':
+			value, n, err := p.expandReference(raw[i:], offset+i, escapes)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(value)
+			i += n - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), nil
+}
+
+// expandReference expands the reference starting with $ at the beginning of s and
+// returns its value and the number of bytes consumed.
+func (p *dotenvParser) expandReference(s string, offset int, escapes bool) (string, int, error) {
+	if len(s) < 2 {
+		return "$", 1, nil
+	}
+	if s[1] != '{' {
+		n := 1
+		for n < len(s) && isNameByte(s[n], n == 1) {
+			n++
 		}
-		if inQuotes {
-			continue
+		if n == 1 {
+			return "$", 1, nil
 		}
-		if char == '#' {
-			return strings.TrimSpace(value[:i])
+		v, _ := p.resolve(s[1:n])
+		return v, n, nil
+	}
+
+	end := matchingBrace(s)
+	if end < 0 {
+		return "", 0, p.errorAt(offset, "unterminated variable reference")
+	}
+	body := s[2:end]
+	n := 0
+	for n < len(body) && isNameByte(body[n], n == 0) {
+		n++
+	}
+	name, op := body[:n], body[n:]
+	if name == "" {
+		return "", 0, p.errorAt(offset, "invalid variable reference ${"+body+"}")
+	}
+
+	v, set := p.resolve(name)
+	var arg string
+	colon := strings.HasPrefix(op, ":")
+	if colon {
+		op = op[1:]
+	}
+	if op != "" {
+		arg = op[1:]
+		op = op[:1]
+	}
+	empty := !set || (colon && v == "")
+	argOffset := offset + 2 + len(body) - len(arg)
+
+	switch op {
+	case "":
+		if colon {
+			return "", 0, p.errorAt(offset, "invalid variable reference ${"+body+"}")
+		}
+		return v, end + 1, nil
+	case "-":
+		if empty {
+			expanded, err := p.expand(arg, argOffset, escapes)
+			return expanded, end + 1, err
+		}
+		return v, end + 1, nil
+	case "+":
+		if empty {
+			return "", end + 1, nil
+		}
+		expanded, err := p.expand(arg, argOffset, escapes)
+		return expanded, end + 1, err
+	case "?":
+		if empty {
+			msg, err := p.expand(arg, argOffset, escapes)
+			if err != nil {
+				return "", 0, err
+			}
+			if msg == "" {
+				msg = "is not set"
+			}
+			return "", 0, p.errorAt(offset, name+": "+msg)
+		}
+		return v, end + 1, nil
+	}
+	return "", 0, p.errorAt(offset, "invalid variable reference ${"+body+"}")
+}
+
+// resolve looks a variable up in the values parsed so far, then in the environment.
+func (p *dotenvParser) resolve(name string) (string, bool) {
+	if v, ok := p.values[name]; ok {
+		return v, true
+	}
+	return p.lookup(name)
+}
+
+// matchingBrace returns the index of the } closing the ${ at the start of s, or -1.
+func matchingBrace(s string) int {
+	depth := 0
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
 		}
 	}
-	return strings.TrimSpace(value)
+	return -1
+}
+
+func isNameByte(c byte, first bool) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (!first && c >= '0' && c <= '9')
+}
+
+func (p *dotenvParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) lineEnd() int {
+	if i := strings.IndexByte(p.src[p.pos:], '\n'); i >= 0 {
+		return p.pos + i
+	}
+	return len(p.src)
+}
+
+func (p *dotenvParser) skipLine() {
+	p.pos = p.lineEnd()
+	if p.pos < len(p.src) {
+		p.pos++
+	}
+}
+
+// errorAt builds a *SyntaxError for the byte offset in the source.
+func (p *dotenvParser) errorAt(offset int, msg string) error {
+	lineStart := strings.LastIndexByte(p.src[:offset], '\n') + 1
+	return &SyntaxError{
+		File:   p.name,
+		Line:   strings.Count(p.src[:offset], "\n") + 1,
+		Column: utf8.RuneCountInString(p.src[lineStart:offset]) + 1,
+		Msg:    msg,
+	}
 }
@@ -0,0 +1,229 @@
+package env
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// redacted replaces secret values in Secret and Dump output.
+const redacted = "[REDACTED]"
+
+// fileSuffix marks a variable holding the path of a file with the value of the
+// variable without it, as used by Docker and Kubernetes secrets.
+const fileSuffix = "_FILE"
+
+// SecretProvider resolves secret references such as secret://db-password.
+type SecretProvider interface {
+	Secret(name string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to SecretProvider.
+type SecretProviderFunc func(name string) (string, error)
+
+func (f SecretProviderFunc) Secret(name string) (string, error) {
+	return f(name)
+}
+
+// DirSecretProvider reads each secret from a file named after it in Dir, e.g.
+// /run/secrets. Trailing line breaks are removed.
+type DirSecretProvider struct {
+	Dir string
+}
+
+func (p DirSecretProvider) Secret(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == ".." {
+		return "", fmt.Errorf("env: invalid secret name %q", name)
+	}
+	return readSecretFile(filepath.Join(p.Dir, name))
+}
+
+var (
+	providersMutex sync.RWMutex
+	providers      = map[string]SecretProvider{}
+)
+
+// RegisterSecretProvider resolves values of the form scheme://name through provider.
+// Values with an unregistered scheme, such as http://, are left untouched.
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	if scheme == "" || provider == nil {
+		panic("env: RegisterSecretProvider requires a scheme and a provider")
+	}
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	providers[scheme] = provider
+}
+
+// SecretError reports a secret that could not be read from a KEY_FILE path or a
+// SecretProvider.
+type SecretError struct {
+	Key    string
+	Source string
+	Err    error
+}
+
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("env: %s: cannot read secret from %s: %v", e.Key, e.Source, e.Err)
+}
+
+func (e *SecretError) Unwrap() error {
+	return e.Err
+}
+
+// Secret is a string that is redacted when printed, logged or marshaled.
+// Bind fills it like a string; use Reveal to read the value.
+type Secret string
+
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return redacted
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// resolve returns the value of key. When key is unset, the file named by KEY_FILE is
+// read instead; values referencing a registered secret provider are resolved.
+func (e *Environment) resolve(key string) (string, bool, error) {
+	raw, found := e.rawValue(key)
+	if !found {
+		path, hasFile := e.rawValue(key + fileSuffix)
+		if !hasFile || path == "" {
+			return "", false, nil
+		}
+		value, err := readSecretFile(path)
+		if err != nil {
+			return "", false, &SecretError{Key: key, Source: path, Err: err}
+		}
+		return value, true, nil
+	}
+
+	provider, name := secretReference(raw)
+	if provider == nil {
+		return raw, true, nil
+	}
+	value, err := provider.Secret(name)
+	if err != nil {
+		return "", false, &SecretError{Key: key, Source: raw, Err: err}
+	}
+	return value, true, nil
+}
+
+// isSecret reports whether the value of key comes from KEY_FILE or a secret provider.
+func (e *Environment) isSecret(key string) bool {
+	raw, found := e.rawValue(key)
+	if !found {
+		path, hasFile := e.rawValue(key + fileSuffix)
+		return hasFile && path != ""
+	}
+	provider, _ := secretReference(raw)
+	return provider != nil
+}
+
+// secretReference returns the provider registered for the scheme of raw and the
+// secret name, or nil if raw is not a secret reference.
+func secretReference(raw string) (SecretProvider, string) {
+	scheme, name, ok := strings.Cut(raw, "://")
+	if !ok {
+		return nil, ""
+	}
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+	return providers[scheme], name
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Dump returns the variables of a bound config struct (or pointer to one) with their
+// current values, for logging or debugging. Secrets are redacted: fields tagged
+// `secret:"true"`, fields of type Secret and variables read from KEY_FILE or a secret
+// provider.
+func Dump(cfg any) map[string]string {
+	return defaultEnv.Dump(cfg)
+}
+
+// Dump is the package-level Dump for a specific Environment.
+func (e *Environment) Dump(cfg any) map[string]string {
+	out := map[string]string{}
+	rv := reflect.ValueOf(cfg)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return out
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return out
+	}
+	visitFields(rv, "", false, func(f boundField) {
+		if isSecretField(f.Field) || e.isSecret(f.Key) {
+			out[f.Key] = redacted
+			return
+		}
+		out[f.Key] = formatValue(f.Value, fieldSeparator(f.Field))
+	})
+	return out
+}
+
+// isSecretField reports whether field is tagged `secret:"true"` or has type Secret.
+func isSecretField(field reflect.StructField) bool {
+	return field.Tag.Get("secret") == "true" || field.Type == reflect.TypeFor[Secret]()
+}
+
+// formatValue renders v the way it would be written in a .env file.
+func formatValue(v reflect.Value, sep string) string {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		if !isScalarType(v.Type()) {
+			return formatValue(v.Elem(), sep)
+		}
+	}
+	switch x := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		if b, err := x.MarshalText(); err == nil {
+			return string(b)
+		}
+	case fmt.Stringer:
+		return x.String()
+	case []byte:
+		return string(x)
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatValue(v.Index(i), sep)
+		}
+		return strings.Join(parts, sep)
+	case reflect.Map:
+		parts := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			parts = append(parts, formatValue(iter.Key(), sep)+"="+formatValue(iter.Value(), sep))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, sep)
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,64 @@
+package env
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/leandroluk/gox/validate"
+	"github.com/leandroluk/gox/validate/schema"
+	"github.com/leandroluk/gox/validate/schema/object"
+)
+
+// validateBound runs the struct in sv through the schema registered for its type in
+// validate's registry, if any. Issues are reported as *InvalidError with variable
+// names in their paths.
+func validateBound(sv reflect.Value) []error {
+	schemaValue, ok := validate.Lookup(sv.Type())
+	if !ok {
+		return nil
+	}
+	_, err := schemaValue.ValidateAny(sv.Interface(), schema.DefaultOptions())
+	if err == nil {
+		return nil
+	}
+	var validationErr *validate.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []error{err}
+	}
+
+	keys := map[string]string{}
+	visitFields(sv, "", false, func(f boundField) {
+		if f.Path != "" {
+			keys[f.Path] = f.Key
+		}
+	})
+	errs := make([]error, 0, len(validationErr.Issues))
+	reported := map[string]bool{}
+	for _, issue := range validationErr.Issues {
+		// A field whose value failed its rules is reported again as undecodable.
+		if issue.Code == object.CodeFieldDecode && reported[issue.Path] {
+			continue
+		}
+		reported[issue.Path] = true
+		errs = append(errs, &InvalidError{
+			Path:    variablePath(issue.Path, keys),
+			Code:    issue.Code,
+			Message: issue.Message,
+		})
+	}
+	return errs
+}
+
+// variablePath replaces the longest field path prefix of path mapped to a variable,
+// turning "db.hosts[1]" into "DB_HOSTS[1]". Paths without a variable are unchanged.
+func variablePath(path string, keys map[string]string) string {
+	for i := len(path); i > 0; i-- {
+		if i < len(path) && path[i] != '.' && path[i] != '[' {
+			continue
+		}
+		if key, ok := keys[path[:i]]; ok {
+			return key + path[i:]
+		}
+	}
+	return path
+}
@@ -0,0 +1,253 @@
+package env
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Watcher polls a set of dotenv files and reloads its Environment when they change.
+type Watcher struct {
+	env     *Environment
+	paths   []string
+	options LoadOptions
+
+	mu       sync.Mutex
+	contents map[string][]byte
+	values   map[string]string
+
+	subsMu sync.RWMutex
+	subs   map[int]func(changed []string)
+	nextID int
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Watch loads paths as in LoadAll and polls them for changes. On every change the files
+// are parsed again, the values are swapped in a single step and callback (if not nil)
+// receives the sorted list of keys that were added, changed or removed. Call Stop to
+// end polling.
+func Watch(paths []string, callback func(changed []string), opts ...LoadOption) (*Watcher, error) {
+	return defaultEnv.Watch(paths, callback, opts...)
+}
+
+// Watch is the package-level Watch for a specific Environment.
+func (e *Environment) Watch(paths []string, callback func(changed []string), opts ...LoadOption) (*Watcher, error) {
+	w := &Watcher{
+		env:      e,
+		paths:    append([]string(nil), paths...),
+		options:  applyLoadOptions(opts),
+		contents: map[string][]byte{},
+		values:   map[string]string{},
+		subs:     map[int]func([]string){},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if callback != nil {
+		w.Subscribe(callback)
+	}
+	if _, err := w.reload(true); err != nil {
+		return nil, err
+	}
+	go w.poll()
+	return w, nil
+}
+
+// Subscribe registers fn to be called with the changed keys after each reload.
+// The returned function removes the subscription.
+func (w *Watcher) Subscribe(fn func(changed []string)) (unsubscribe func()) {
+	w.subsMu.Lock()
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+	w.subsMu.Unlock()
+	return func() {
+		w.subsMu.Lock()
+		delete(w.subs, id)
+		w.subsMu.Unlock()
+	}
+}
+
+// Reload checks the files immediately and returns the changed keys.
+func (w *Watcher) Reload() ([]string, error) {
+	return w.reload(false)
+}
+
+// Stop ends polling and waits for an in-flight reload to finish.
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+func (w *Watcher) poll() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if _, err := w.reload(false); err != nil && w.options.OnError != nil {
+				w.options.OnError(err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) reload(initial bool) ([]string, error) {
+	w.mu.Lock()
+	contents := map[string][]byte{}
+	for _, path := range w.paths {
+		if path == "" {
+			continue
+		}
+		data, found, err := readEnvFile(path)
+		if err != nil {
+			w.mu.Unlock()
+			return nil, err
+		}
+		if found {
+			contents[path] = data
+		}
+	}
+	if !initial && sameContents(w.contents, contents) {
+		w.mu.Unlock()
+		return nil, nil
+	}
+
+	read := func(path string) ([]byte, bool, error) {
+		data, found := contents[path]
+		return data, found, nil
+	}
+	values, _, err := w.env.collect(w.paths, w.options, read, w.values)
+	if err != nil {
+		w.mu.Unlock()
+		return nil, err
+	}
+
+	var changed, removed []string
+	for key, value := range values {
+		if old, ok := w.values[key]; !ok || old != value {
+			changed = append(changed, key)
+		}
+	}
+	for key := range w.values {
+		if _, ok := values[key]; !ok {
+			changed = append(changed, key)
+			removed = append(removed, key)
+		}
+	}
+	w.env.swap(values, removed)
+	w.contents, w.values = contents, values
+	w.mu.Unlock()
+
+	sort.Strings(changed)
+	if len(changed) > 0 && !initial {
+		w.notify(changed)
+	}
+	return changed, nil
+}
+
+func (w *Watcher) notify(changed []string) {
+	w.subsMu.RLock()
+	ids := make([]int, 0, len(w.subs))
+	for id := range w.subs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subs := make([]func([]string), len(ids))
+	for i, id := range ids {
+		subs[i] = w.subs[id]
+	}
+	w.subsMu.RUnlock()
+
+	for _, fn := range subs {
+		fn(append([]string(nil), changed...))
+	}
+}
+
+func sameContents(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, data := range a {
+		other, ok := b[path]
+		if !ok || !bytes.Equal(data, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// swap sets values and deletes removed keys in a single step.
+func (e *Environment) swap(values map[string]string, removed []string) {
+	e.mu.Lock()
+	for key, value := range values {
+		e.store[key] = value
+	}
+	for _, key := range removed {
+		delete(e.store, key)
+	}
+	e.mu.Unlock()
+
+	if e.system {
+		for key, value := range values {
+			_ = os.Setenv(key, value)
+		}
+		for _, key := range removed {
+			_ = os.Unsetenv(key)
+		}
+	}
+}
+
+// Live holds a struct bound from a watched Environment and re-bound after each change.
+type Live[T any] struct {
+	value atomic.Pointer[T]
+	err   atomic.Pointer[error]
+	stop  func()
+}
+
+// BindLive binds T from the watcher's Environment and re-binds it whenever its files
+// change. A failed re-bind keeps the previous value and is reported by Err.
+func BindLive[T any](w *Watcher) (*Live[T], error) {
+	initial, err := BindFrom[T](w.env)
+	if err != nil {
+		return nil, err
+	}
+	l := &Live[T]{}
+	l.value.Store(&initial)
+	l.stop = w.Subscribe(func([]string) {
+		next, err := BindFrom[T](w.env)
+		if err != nil {
+			l.err.Store(&err)
+			return
+		}
+		l.value.Store(&next)
+		l.err.Store(nil)
+	})
+	return l, nil
+}
+
+// Get returns the last successfully bound value.
+func (l *Live[T]) Get() T {
+	return *l.value.Load()
+}
+
+// Err returns the error of the last re-bind, or nil if it succeeded.
+func (l *Live[T]) Err() error {
+	if err := l.err.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Close stops re-binding on change.
+func (l *Live[T]) Close() {
+	l.stop()
+}
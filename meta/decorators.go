@@ -2,12 +2,26 @@ package meta
 
 import "reflect"
 
-// Description adds a text documentation to an object or field.
-type descriptionDecorator struct{ Text string }
+// Description adds a text documentation to an object or field, either a string or
+// a Text keyed by locale.
+type descriptionDecorator struct {
+	Text      string
+	Localized Text
+}
 
-func Description(text string) descriptionDecorator                    { return descriptionDecorator{Text: text} }
-func (d descriptionDecorator) applyToObject(_ any, m *ObjectMetadata) { m.Description = d.Text }
-func (d descriptionDecorator) applyToField(m *FieldMetadata)          { m.Description = d.Text }
+func Description[S string | Text](text S) descriptionDecorator {
+	value, localized := textOf(text)
+	return descriptionDecorator{Text: value, Localized: localized}
+}
+func (d descriptionDecorator) applyToObject(_ any, m *ObjectMetadata) {
+	m.Description, m.Descriptions = d.Text, d.Localized
+}
+func (d descriptionDecorator) applyToField(m *FieldMetadata) {
+	m.Description, m.Descriptions = d.Text, d.Localized
+}
+func (d descriptionDecorator) applyToOperation(m *OperationMetadata) {
+	m.Description, m.Descriptions = d.Text, d.Localized
+}
 
 // Example adds a sample value to an object or field.
 type exampleDecorator struct{ Value any }
@@ -16,11 +30,19 @@ func Example[T any](val T) exampleDecorator                       { return examp
 func (d exampleDecorator) applyToObject(_ any, m *ObjectMetadata) { m.Example = d.Value }
 func (d exampleDecorator) applyToField(m *FieldMetadata)          { m.Example = d.Value }
 
-// Title sets a title for an object.
-type titleDecorator struct{ Text string }
+// Title sets a title for an object, either a string or a Text keyed by locale.
+type titleDecorator struct {
+	Text      string
+	Localized Text
+}
 
-func Title(text string) titleDecorator                          { return titleDecorator{Text: text} }
-func (d titleDecorator) applyToObject(_ any, m *ObjectMetadata) { m.Title = d.Text }
+func Title[S string | Text](text S) titleDecorator {
+	value, localized := textOf(text)
+	return titleDecorator{Text: value, Localized: localized}
+}
+func (d titleDecorator) applyToObject(_ any, m *ObjectMetadata) {
+	m.Title, m.Titles = d.Text, d.Localized
+}
 
 // Required marks a field as required in the schema.
 type requiredDecorator struct{}
@@ -99,6 +121,9 @@ func (d deprecatedDecorator) applyToObject(_ any, m *ObjectMetadata) {
 func (d deprecatedDecorator) applyToField(m *FieldMetadata) {
 	m.Deprecated = true
 }
+func (d deprecatedDecorator) applyToOperation(m *OperationMetadata) {
+	m.Deprecated = true
+}
 
 // ExternalDocs
 
@@ -113,6 +138,9 @@ func (d externalDocsDecorator) applyToObject(_ any, m *ObjectMetadata) {
 func (d externalDocsDecorator) applyToField(m *FieldMetadata) {
 	m.ExternalDocs = &d.Docs
 }
+func (d externalDocsDecorator) applyToOperation(m *OperationMetadata) {
+	m.ExternalDocs = &d.Docs
+}
 
 // Throws documents potential errors.
 type throwsDecorator ThrowsMetadata
@@ -131,6 +159,9 @@ func Throws[T error](optionalDescription ...string) throwsDecorator {
 func (d throwsDecorator) applyToObject(_ any, m *ObjectMetadata) {
 	m.Throws = append(m.Throws, ThrowsMetadata(d))
 }
+func (d throwsDecorator) applyToOperation(m *OperationMetadata) {
+	m.Throws = append(m.Throws, ThrowsMetadata(d))
+}
 
 // Field targets a specific field in the struct for documentation using its memory address.
 type fieldDecorator struct {
@@ -144,7 +175,12 @@ func (d fieldDecorator) applyToObject(sPtr any, m *ObjectMetadata) {
 	if name == "" {
 		panic("meta: could not resolve field name - ensure you are passing a pointer to the struct's field")
 	}
+	applyField(m, name, jsonName, fieldType, d.Options)
+}
 
+// applyField creates or updates the metadata of the field registered under name and
+// applies options to it.
+func applyField(m *ObjectMetadata, name, jsonName string, fieldType reflect.Type, options []FieldOption) {
 	fMeta, exists := m.Fields[name]
 	if !exists {
 		fMeta = &FieldMetadata{}
@@ -176,7 +212,7 @@ func (d fieldDecorator) applyToObject(sPtr any, m *ObjectMetadata) {
 		}
 	}
 
-	for _, opt := range d.Options {
+	for _, opt := range options {
 		opt.applyToField(fMeta)
 	}
 
@@ -0,0 +1,451 @@
+package meta
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ExampleOptions configures GenerateExample.
+type ExampleOptions struct {
+	// Seed drives every generated value; equal seeds produce equal examples. Default: 0.
+	Seed uint64
+}
+
+type ExampleOption func(*ExampleOptions)
+
+// WithSeed sets the seed of the generated values.
+func WithSeed(seed uint64) ExampleOption {
+	return func(o *ExampleOptions) { o.Seed = seed }
+}
+
+// exampleEpoch is the base of generated dates.
+var exampleEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// GenerateExample builds an instance of T for documentation and contract tests.
+// Field Example values are used as is; other fields get values satisfying their
+// registered constraints (Enum, Pattern, Format, Min, Max, MultipleOf, lengths and
+// item counts). Nested structs, pointers, slices and maps are filled recursively,
+// stopping at recursive references. Numeric bounds are clamped to the range of the
+// field's kind, and interface types yield their zero value. The output is
+// deterministic for a given seed.
+func GenerateExample[T any](options ...ExampleOption) T {
+	example, _ := GenerateExampleOf(reflect.TypeFor[T](), options...).(T)
+	return example
+}
+
+// GenerateExampleOf builds an example of t, as GenerateExample does.
+func GenerateExampleOf(t reflect.Type, options ...ExampleOption) any {
+	o := ExampleOptions{}
+	for _, option := range options {
+		if option != nil {
+			option(&o)
+		}
+	}
+	g := &exampleGenerator{
+		rand:     rand.New(rand.NewPCG(o.Seed, o.Seed^0x9e3779b97f4a7c15)),
+		visiting: make(map[reflect.Type]bool),
+	}
+	v := reflect.New(t).Elem()
+	g.fill(v, nil, nil)
+	return v.Interface()
+}
+
+// exampleGenerator fills values from metadata. visiting holds the structs being
+// filled, so recursive references are left empty.
+type exampleGenerator struct {
+	rand     *rand.Rand
+	visiting map[reflect.Type]bool
+}
+
+// fill sets v from the metadata of its field, if any. nested holds metadata
+// registered for the fields of a struct v through its parent.
+func (g *exampleGenerator) fill(v reflect.Value, fm *FieldMetadata, nested map[string]*FieldMetadata) {
+	t := v.Type()
+	if fm != nil && fm.Example != nil && setExample(v, fm.Example) {
+		return
+	}
+
+	if t.Kind() == reflect.Pointer {
+		if g.recursive(t.Elem()) {
+			return
+		}
+		v.Set(reflect.New(t.Elem()))
+		g.fill(v.Elem(), fm, nested)
+		return
+	}
+	if override := GetTypeOverride(t); override != nil && override.Field.Example != nil && setExample(v, override.Field.Example) {
+		return
+	}
+
+	if values := enumValues(t); len(values) > 0 {
+		setString(v, values[g.rand.IntN(len(values))])
+		return
+	}
+
+	switch {
+	case t == timeType:
+		v.Set(reflect.ValueOf(g.date()))
+		return
+	case t == durationType:
+		v.SetInt(int64(g.between(1, 120)) * int64(time.Minute))
+		return
+	case t == rawMessageType:
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(g.text(fm))
+	case reflect.Bool:
+		v.SetBool(g.rand.IntN(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		floor, ceil := numberRange(t)
+		v.SetInt(int64(g.number(fm, true, floor, ceil)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		floor, ceil := numberRange(t)
+		v.SetUint(uint64(g.number(fm, true, floor, ceil)))
+	case reflect.Float32, reflect.Float64:
+		floor, ceil := numberRange(t)
+		v.SetFloat(g.number(fm, false, floor, ceil))
+	case reflect.Slice:
+		if g.recursive(t.Elem()) {
+			return
+		}
+		count := g.items(fm)
+		v.Set(reflect.MakeSlice(t, count, count))
+		for i := 0; i < count; i++ {
+			g.fill(v.Index(i), nil, nil)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			g.fill(v.Index(i), nil, nil)
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || g.recursive(t.Elem()) {
+			return
+		}
+		key := reflect.New(t.Key()).Elem()
+		key.SetString("key")
+		value := reflect.New(t.Elem()).Elem()
+		g.fill(value, nil, nil)
+		v.Set(reflect.MakeMap(t))
+		v.SetMapIndex(key, value)
+	case reflect.Struct:
+		g.object(v, nested)
+	}
+}
+
+// recursive reports whether t, or the type it points to, is a struct being filled.
+func (g *exampleGenerator) recursive(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return g.visiting[t]
+}
+
+// object fills the exported fields of the struct v.
+func (g *exampleGenerator) object(v reflect.Value, nested map[string]*FieldMetadata) {
+	t := v.Type()
+	m := GetObjectMetadataByType(t)
+	if nested == nil && m != nil && m.Example != nil && setExample(v, m.Example) {
+		return
+	}
+	if isTextType(t) {
+		return
+	}
+
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+	g.fields(v, mergeFields(objectFields(t), nested))
+}
+
+// fields fills the fields of the struct v, including those promoted from embedded
+// structs, with the metadata in fields keyed by Go field path.
+func (g *exampleGenerator) fields(v reflect.Value, fields map[string]*FieldMetadata) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if strings.Split(field.Tag.Get("json"), ",")[0] == "-" {
+			continue
+		}
+		fv := v.Field(i)
+
+		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if field.Type.Kind() == reflect.Pointer {
+					if !fv.CanSet() {
+						continue
+					}
+					fv.Set(reflect.New(embedded))
+					fv = fv.Elem()
+				}
+				g.fields(fv, mergeFields(objectFields(embedded), fields))
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		g.fill(fv, fields[field.Name], nestedFields(fields, field.Name))
+	}
+}
+
+// text returns a string satisfying the Enum, Pattern, Format and length constraints
+// of fm, in that order of precedence.
+func (g *exampleGenerator) text(fm *FieldMetadata) string {
+	if fm == nil {
+		return g.word(8)
+	}
+	if len(fm.Enum) > 0 {
+		return fm.Enum[g.rand.IntN(len(fm.Enum))]
+	}
+	if fm.Pattern != "" {
+		if re, err := syntax.Parse(fm.Pattern, syntax.Perl); err == nil {
+			re = re.Simplify()
+			var value string
+			for attempt := 0; attempt < 10; attempt++ {
+				var b strings.Builder
+				g.regexp(&b, re)
+				value = b.String()
+				if fitsLength(value, fm) {
+					break
+				}
+			}
+			return value
+		}
+	}
+
+	switch fm.Format {
+	case "email":
+		return g.word(6) + "@example.com"
+	case "uuid":
+		return g.uuid()
+	case "date-time":
+		return g.date().Format(time.RFC3339)
+	case "date":
+		return g.date().Format(time.DateOnly)
+	case "uri", "url":
+		return "https://example.com/" + g.word(6)
+	}
+
+	length := 8
+	if fm.MinLength != nil && length < *fm.MinLength {
+		length = *fm.MinLength
+	}
+	if fm.MaxLength != nil && length > *fm.MaxLength {
+		length = *fm.MaxLength
+	}
+	return g.word(length)
+}
+
+// number returns a number within the Min, Max and MultipleOf constraints of fm,
+// rounded to an integer or to two decimals. Without bounds it lies in [0, 100].
+// The constraints are clamped to [floor, ceil], the range of the field's kind.
+func (g *exampleGenerator) number(fm *FieldMetadata, integer bool, floor, ceil float64) float64 {
+	low, high := 0.0, 100.0
+	if fm != nil && fm.Min != nil {
+		low = *fm.Min
+		if fm.Max == nil {
+			high = low + 100
+		}
+	}
+	if fm != nil && fm.Max != nil {
+		high = *fm.Max
+		if fm.Min == nil && high < low {
+			low = high - 100
+		}
+	}
+	low = math.Min(math.Max(low, floor), ceil)
+	high = math.Min(math.Max(high, floor), ceil)
+	if integer {
+		low, high = math.Ceil(low), math.Floor(high)
+	}
+	if high < low {
+		return low
+	}
+
+	if fm != nil && fm.MultipleOf != nil && *fm.MultipleOf > 0 {
+		step := *fm.MultipleOf
+		first, last := math.Ceil(low/step), math.Floor(high/step)
+		if last >= first {
+			value := g.integer(first, last) * step
+			if integer {
+				value = math.Round(value)
+			}
+			return math.Min(math.Max(value, low), high)
+		}
+	}
+
+	if integer {
+		return g.integer(low, high)
+	}
+	value := math.Round((low+g.rand.Float64()*(high-low))*100) / 100
+	return math.Min(math.Max(value, low), high)
+}
+
+// integer returns an integer in [low, high]. Ranges too wide for Int64N are drawn
+// with float precision.
+func (g *exampleGenerator) integer(low, high float64) float64 {
+	if span := high - low; span < 1<<53 {
+		return low + float64(g.rand.Int64N(int64(span)+1))
+	}
+	return math.Min(math.Floor(low+g.rand.Float64()*(high-low)), high)
+}
+
+// numberRange returns the bounds of the values representable by the numeric kind
+// of t. Integer bounds are the widest that convert back to t without overflow.
+func numberRange(t reflect.Type) (float64, float64) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		limit := math.Ldexp(1, t.Bits()-1)
+		return -limit, math.Floor(math.Nextafter(limit, 0))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 0, math.Floor(math.Nextafter(math.Ldexp(1, t.Bits()), 0))
+	case reflect.Float32:
+		return -math.MaxFloat32, math.MaxFloat32
+	}
+	return math.Inf(-1), math.Inf(1)
+}
+
+// items returns the length of a slice within the MinItems and MaxItems constraints
+// of fm, defaulting to one item.
+func (g *exampleGenerator) items(fm *FieldMetadata) int {
+	count := 1
+	if fm != nil && fm.MinItems != nil && count < *fm.MinItems {
+		count = *fm.MinItems
+	}
+	if fm != nil && fm.MaxItems != nil && count > *fm.MaxItems {
+		count = *fm.MaxItems
+	}
+	return count
+}
+
+// regexp writes a string matching re, repeating unbounded expressions at most
+// three extra times.
+func (g *exampleGenerator) regexp(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(g.class(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteString(g.word(1))
+	case syntax.OpCapture:
+		g.regexp(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.regexp(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.regexp(b, re.Sub[g.rand.IntN(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			low, high = 0, 3
+		case syntax.OpPlus:
+			low, high = 1, 4
+		case syntax.OpQuest:
+			low, high = 0, 1
+		}
+		if high < 0 {
+			high = low + 3
+		}
+		for n := g.between(low, high); n > 0; n-- {
+			g.regexp(b, re.Sub[0])
+		}
+	}
+}
+
+// class returns a rune of the character class ranges, preferring printable ASCII.
+func (g *exampleGenerator) class(ranges []rune) rune {
+	var printable []rune
+	for r := rune('!'); r <= '~'; r++ {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= r && r <= ranges[i+1] {
+				printable = append(printable, r)
+				break
+			}
+		}
+	}
+	if len(printable) > 0 {
+		return printable[g.rand.IntN(len(printable))]
+	}
+	if len(ranges) < 2 {
+		return 'x'
+	}
+	i := g.rand.IntN(len(ranges)/2) * 2
+	return ranges[i] + g.rand.Int32N(ranges[i+1]-ranges[i]+1)
+}
+
+// word returns length random lowercase letters.
+func (g *exampleGenerator) word(length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = byte('a' + g.rand.IntN(26))
+	}
+	return string(b)
+}
+
+// uuid returns a random version 4 UUID.
+func (g *exampleGenerator) uuid() string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(g.rand.IntN(256))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// date returns a date within a year of exampleEpoch, truncated to seconds.
+func (g *exampleGenerator) date() time.Time {
+	return exampleEpoch.Add(time.Duration(g.rand.Int64N(365*24*3600)) * time.Second)
+}
+
+func (g *exampleGenerator) between(low, high int) int {
+	if high <= low {
+		return low
+	}
+	return low + g.rand.IntN(high-low+1)
+}
+
+// fitsLength reports whether value satisfies the length constraints of fm.
+func fitsLength(value string, fm *FieldMetadata) bool {
+	length := utf8.RuneCountInString(value)
+	return (fm.MinLength == nil || length >= *fm.MinLength) && (fm.MaxLength == nil || length <= *fm.MaxLength)
+}
+
+// setExample assigns example to v when its type is assignable or convertible.
+func setExample(v reflect.Value, example any) bool {
+	ev := reflect.ValueOf(example)
+	switch {
+	case ev.Type().AssignableTo(v.Type()):
+		v.Set(ev)
+	case v.Kind() == reflect.Pointer && ev.Kind() == v.Type().Elem().Kind() && ev.Type().ConvertibleTo(v.Type().Elem()):
+		v.Set(reflect.New(v.Type().Elem()))
+		v.Elem().Set(ev.Convert(v.Type().Elem()))
+	case ev.Kind() == v.Kind() && ev.Type().ConvertibleTo(v.Type()):
+		v.Set(ev.Convert(v.Type()))
+	default:
+		return false
+	}
+	return true
+}
+
+// setString assigns an enum value to v, of a string kind.
+func setString(v reflect.Value, value string) {
+	if v.Kind() == reflect.String {
+		v.SetString(value)
+	}
+}
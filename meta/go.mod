@@ -1,3 +1,8 @@
 module github.com/leandroluk/gox/meta
 
 go 1.25
+
+require (
+	github.com/leandroluk/gox/oas v0.1.0
+	github.com/leandroluk/gox/validate v0.1.0
+)
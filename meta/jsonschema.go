@@ -0,0 +1,243 @@
+package meta
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JSONSchemaDialect is the $schema of documents produced by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document for T built from the
+// registered ObjectMetadata. Named structs referenced by T are placed in $defs and
+// referenced with $ref; references back to T point to the document root. Titles
+// and descriptions are exported in the locale set with WithLocale.
+func JSONSchema[T any](options ...SchemaOption) map[string]any {
+	return JSONSchemaOf(reflect.TypeFor[T](), options...)
+}
+
+// JSONSchemaOf returns a JSON Schema (draft 2020-12) document for t.
+func JSONSchemaOf(t reflect.Type, options ...SchemaOption) map[string]any {
+	g := &jsonSchemaGenerator{
+		locale: applySchemaOptions(options).Locale,
+		defs:   make(map[string]any),
+		names:  make(map[reflect.Type]string),
+		taken:  make(map[string]reflect.Type),
+	}
+
+	root := t
+	if root.Kind() == reflect.Pointer {
+		root = root.Elem()
+	}
+	var doc map[string]any
+	if isReference(root, nil) {
+		g.names[root] = ""
+		doc = g.object(root, objectFields(root))
+		if m := GetObjectMetadataByType(root); m != nil {
+			applyObjectAnnotations(doc, m, g.locale)
+		}
+		if root != t {
+			doc = nullable(doc)
+		}
+	} else {
+		doc = g.build(t, nil)
+	}
+
+	doc["$schema"] = JSONSchemaDialect
+	if len(g.defs) > 0 {
+		doc["$defs"] = g.defs
+	}
+	return doc
+}
+
+// jsonSchemaGenerator collects the $defs of a JSON Schema document. The root type
+// is registered with an empty name, so recursive references point to "#".
+type jsonSchemaGenerator struct {
+	locale string
+	defs   map[string]any
+	names  map[reflect.Type]string
+	taken  map[string]reflect.Type
+}
+
+// ref registers the struct type t in $defs once and returns its $ref.
+func (g *jsonSchemaGenerator) ref(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		if name == "" {
+			return "#"
+		}
+		return "#/$defs/" + escapeJSONPointer(name)
+	}
+	name := uniqueComponentName(t, g.taken)
+	g.names[t] = name
+	g.taken[name] = t
+
+	schema := g.object(t, objectFields(t))
+	if m := GetObjectMetadataByType(t); m != nil {
+		applyObjectAnnotations(schema, m, g.locale)
+	}
+	g.defs[name] = schema
+	return "#/$defs/" + escapeJSONPointer(name)
+}
+
+// escapeJSONPointer escapes name for use as a JSON Pointer (RFC 6901) token.
+func escapeJSONPointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// build returns the schema of t. nested holds metadata registered for the fields
+// of t through a parent struct; when present, t is inlined instead of referenced.
+func (g *jsonSchemaGenerator) build(t reflect.Type, nested map[string]*FieldMetadata) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		return nullable(g.build(t.Elem(), nested))
+	}
+	if override := GetTypeOverride(t); override != nil {
+		schema := map[string]any{"type": override.SchemaType}
+		applyFieldAnnotations(schema, override.Field, g.locale)
+		return schema
+	}
+	if values := enumValues(t); values != nil {
+		return map[string]any{"type": "string", "enum": values}
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]any{}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case isTextType(t):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.build(t.Elem(), nil)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.build(t.Elem(), nil)}
+	case reflect.Struct:
+		if nested == nil && componentName(t) != "" {
+			return map[string]any{"$ref": g.ref(t)}
+		}
+		return g.object(t, mergeFields(objectFields(t), nested))
+	}
+	return map[string]any{}
+}
+
+// object returns the schema of the struct type t.
+func (g *jsonSchemaGenerator) object(t reflect.Type, fields map[string]*FieldMetadata) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for _, field := range schemaFields(t, fields) {
+		schema := g.build(field.Type, field.Nested)
+		if field.Metadata != nil {
+			applyFieldAnnotations(schema, field.Metadata, g.locale)
+			if field.Metadata.Required {
+				required = append(required, field.Name)
+			}
+		}
+		properties[field.Name] = schema
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// nullable allows null in addition to schema.
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		if enum, ok := schema["enum"].([]string); ok {
+			schema["enum"] = nullableEnum(enum)
+		}
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+// nullableEnum returns enum with null appended, so a nullable enum accepts null.
+func nullableEnum(enum []string) []any {
+	values := make([]any, 0, len(enum)+1)
+	for _, value := range enum {
+		values = append(values, value)
+	}
+	return append(values, nil)
+}
+
+func applyObjectAnnotations(schema map[string]any, m *ObjectMetadata, locale string) {
+	if title := m.LocalizedTitle(locale); title != "" {
+		schema["title"] = title
+	}
+	if description := m.LocalizedDescription(locale); description != "" {
+		schema["description"] = description
+	}
+	if m.Deprecated {
+		schema["deprecated"] = true
+	}
+	if m.Example != nil {
+		schema["examples"] = []any{m.Example}
+	}
+}
+
+func applyFieldAnnotations(schema map[string]any, fm *FieldMetadata, locale string) {
+	if description := fm.LocalizedDescription(locale); description != "" {
+		schema["description"] = description
+	}
+	if fm.Example != nil {
+		schema["examples"] = []any{fm.Example}
+	}
+	if fm.Format != "" {
+		schema["format"] = fm.Format
+	}
+	if fm.ReadOnly {
+		schema["readOnly"] = true
+	}
+	if fm.WriteOnly {
+		schema["writeOnly"] = true
+	}
+	if fm.Deprecated {
+		schema["deprecated"] = true
+	}
+	if _, ok := schema["enum"]; !ok && len(fm.Enum) > 0 {
+		schema["enum"] = fm.Enum
+		if typ, ok := schema["type"].([]string); ok && slices.Contains(typ, "null") {
+			schema["enum"] = nullableEnum(fm.Enum)
+		}
+	}
+	if fm.Min != nil {
+		schema["minimum"] = *fm.Min
+	}
+	if fm.Max != nil {
+		schema["maximum"] = *fm.Max
+	}
+	if fm.MultipleOf != nil {
+		schema["multipleOf"] = *fm.MultipleOf
+	}
+	if fm.MinLength != nil {
+		schema["minLength"] = *fm.MinLength
+	}
+	if fm.MaxLength != nil {
+		schema["maxLength"] = *fm.MaxLength
+	}
+	if fm.Pattern != "" {
+		schema["pattern"] = fm.Pattern
+	}
+	if fm.MinItems != nil {
+		schema["minItems"] = *fm.MinItems
+	}
+	if fm.MaxItems != nil {
+		schema["maxItems"] = *fm.MaxItems
+	}
+}
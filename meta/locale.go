@@ -0,0 +1,90 @@
+package meta
+
+// DefaultLocale is the locale used when a Text has no entry for the requested one,
+// and by exporters when no locale is set.
+var DefaultLocale = "en"
+
+// Text holds a documentation text keyed by locale (e.g. "en", "pt-BR").
+type Text map[string]string
+
+// Get returns the text for locale, falling back to its base language ("pt" for
+// "pt-BR"), then to DefaultLocale and its base language. An empty locale selects
+// DefaultLocale.
+func (t Text) Get(locale string) string {
+	for _, candidate := range [...]string{locale, baseLanguage(locale), DefaultLocale, baseLanguage(DefaultLocale)} {
+		if candidate == "" {
+			continue
+		}
+		if text, ok := t[candidate]; ok {
+			return text
+		}
+	}
+	return ""
+}
+
+func baseLanguage(locale string) string {
+	for i := 0; i < len(locale); i++ {
+		if locale[i] == '-' || locale[i] == '_' {
+			return locale[:i]
+		}
+	}
+	return ""
+}
+
+// textOf converts the argument of Title or Description into its default text and
+// its localized variants, which are nil for a plain string.
+func textOf[S string | Text](text S) (string, Text) {
+	switch value := any(text).(type) {
+	case Text:
+		return value.Get(DefaultLocale), value
+	default:
+		return value.(string), nil
+	}
+}
+
+// LocalizedTitle returns the title of the object in locale.
+func (m *ObjectMetadata) LocalizedTitle(locale string) string {
+	if m.Titles != nil {
+		return m.Titles.Get(locale)
+	}
+	return m.Title
+}
+
+// LocalizedDescription returns the description of the object in locale.
+func (m *ObjectMetadata) LocalizedDescription(locale string) string {
+	if m.Descriptions != nil {
+		return m.Descriptions.Get(locale)
+	}
+	return m.Description
+}
+
+// LocalizedDescription returns the description of the field in locale.
+func (fm *FieldMetadata) LocalizedDescription(locale string) string {
+	if fm.Descriptions != nil {
+		return fm.Descriptions.Get(locale)
+	}
+	return fm.Description
+}
+
+// SchemaOptions configures the OpenAPI and JSON Schema exporters.
+type SchemaOptions struct {
+	// Locale selects the Text used for titles and descriptions. Default: DefaultLocale.
+	Locale string
+}
+
+type SchemaOption func(*SchemaOptions)
+
+// WithLocale sets the locale of exported titles and descriptions.
+func WithLocale(locale string) SchemaOption {
+	return func(o *SchemaOptions) { o.Locale = locale }
+}
+
+func applySchemaOptions(optionList []SchemaOption) SchemaOptions {
+	options := SchemaOptions{}
+	for _, option := range optionList {
+		if option != nil {
+			option(&options)
+		}
+	}
+	return options
+}
@@ -1,10 +1,18 @@
 package meta_test
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/leandroluk/gox/meta"
+	"github.com/leandroluk/gox/oas"
+	"github.com/leandroluk/gox/validate"
 )
 
 // Helper types for tests
@@ -252,3 +260,784 @@ func TestMeta_DescribeMixedOptions(t *testing.T) {
 		t.Error("Mixed options fail")
 	}
 }
+
+type OASAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type OASCustomer struct {
+	ID       string         `json:"id"`
+	Email    *string        `json:"email,omitempty"`
+	Age      int32          `json:"age"`
+	Score    float64        `json:"score"`
+	Address  OASAddress     `json:"address"`
+	Billing  *OASAddress    `json:"billing"`
+	Shipping OASAddress     `json:"shipping"`
+	Tags     []string       `json:"tags"`
+	Attrs    map[string]int `json:"attrs"`
+	Created  time.Time      `json:"created"`
+	Status   EnumType       `json:"status"`
+	Parent   *OASCustomer   `json:"parent,omitempty"`
+	Raw      []byte         `json:"raw"`
+	Ignored  string         `json:"-"`
+	Extra    map[string]any `json:"extra"`
+	internal string
+}
+
+func TestMeta_OpenAPI(t *testing.T) {
+	a := &OASAddress{}
+	meta.Describe(a, meta.Description("Postal address"), meta.Field(&a.City, meta.Required()))
+
+	c := &OASCustomer{}
+	meta.Describe(c,
+		meta.Title("Customer"),
+		meta.Field(&c.ID, meta.Required(), meta.Format("uuid"), meta.ReadOnly()),
+		meta.Field(&c.Age, meta.Min(18), meta.Max(130)),
+		meta.Field(&c.Tags, meta.MinItems(1), meta.MaxItems(5)),
+		meta.Field(&c.Address, meta.Description("Main address")),
+		meta.Field(&c.Shipping.Street, meta.MinLength(3)),
+	)
+
+	components := &oas.Components{}
+	g := meta.NewOpenAPIGenerator(components)
+	root := &oas.Schema{}
+	meta.OpenAPISchema[[]*OASCustomer](g)(root)
+
+	if got := mustJSON(t, root); got != `{"type":"array","items":{"nullable":true,"allOf":[{"$ref":"#/components/schemas/OASCustomer"}]}}` {
+		t.Errorf("unexpected root schema: %s", got)
+	}
+	if ref := g.Register(reflect.TypeFor[*OASAddress]()); ref != "#/components/schemas/OASAddress" {
+		t.Errorf("unexpected ref: %s", ref)
+	}
+
+	var doc struct {
+		Schemas map[string]map[string]any `json:"schemas"`
+	}
+	if err := json.Unmarshal([]byte(mustJSON(t, components)), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Schemas) != 2 {
+		t.Fatalf("expected 2 components, got %v", doc.Schemas)
+	}
+	if got := mustJSON(t, doc.Schemas["OASAddress"]); got != `{"description":"Postal address","properties":{"city":{"type":"string"},"street":{"type":"string"}},"required":["city"],"type":"object"}` {
+		t.Errorf("unexpected address: %s", got)
+	}
+
+	customer := doc.Schemas["OASCustomer"]
+	if customer["title"] != "Customer" || mustJSON(t, customer["required"]) != `["id"]` {
+		t.Errorf("unexpected customer: %v", customer)
+	}
+	properties := customer["properties"].(map[string]any)
+	for name, want := range map[string]string{
+		"id":       `{"format":"uuid","readOnly":true,"type":"string"}`,
+		"email":    `{"nullable":true,"type":"string"}`,
+		"age":      `{"format":"int32","maximum":130,"minimum":18,"type":"integer"}`,
+		"score":    `{"format":"double","type":"number"}`,
+		"address":  `{"allOf":[{"$ref":"#/components/schemas/OASAddress"}],"description":"Main address"}`,
+		"billing":  `{"allOf":[{"$ref":"#/components/schemas/OASAddress"}],"nullable":true}`,
+		"shipping": `{"properties":{"city":{"type":"string"},"street":{"minLength":3,"type":"string"}},"required":["city"],"type":"object"}`,
+		"tags":     `{"items":{"type":"string"},"maxItems":5,"minItems":1,"type":"array"}`,
+		"attrs":    `{"additionalProperties":{"format":"int64","type":"integer"},"type":"object"}`,
+		"created":  `{"format":"date-time","type":"string"}`,
+		"status":   `{"enum":["A","B","C"],"type":"string"}`,
+		"parent":   `{"allOf":[{"$ref":"#/components/schemas/OASCustomer"}],"nullable":true}`,
+		"raw":      `{"format":"byte","type":"string"}`,
+		"extra":    `{"additionalProperties":{},"type":"object"}`,
+	} {
+		if got := mustJSON(t, properties[name]); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+	if len(properties) != 14 {
+		t.Errorf("expected 14 properties, got %d", len(properties))
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+type JSONNode struct {
+	Name     string      `json:"name"`
+	Weight   float64     `json:"weight"`
+	Children []*JSONNode `json:"children"`
+	Address  *OASAddress `json:"address"`
+	Home     OASAddress  `json:"home"`
+	Status   *EnumType   `json:"status"`
+	Kind     *string     `json:"kind" meta:"enum=a|b"`
+	Data     []byte      `json:"data"`
+}
+
+func TestMeta_JSONSchema(t *testing.T) {
+	a := &OASAddress{}
+	meta.Describe(a, meta.Description("Postal address"), meta.Field(&a.City, meta.Required()))
+
+	n := &JSONNode{}
+	meta.Describe(n,
+		meta.Title("Node"),
+		meta.Example(map[string]any{"name": "root"}),
+		meta.Field(&n.Name, meta.Required(), meta.MinLength(1), meta.MaxLength(20), meta.Pattern("^[a-z]+$")),
+		meta.Field(&n.Weight, meta.Min(0), meta.Max(1), meta.MultipleOf(0.5), meta.Example(0.5)),
+		meta.Field(&n.Children, meta.MinItems(1), meta.MaxItems(3)),
+		meta.Field(&n.Home, meta.Description("Home address")),
+	)
+
+	doc := meta.JSONSchema[JSONNode]()
+	want := `{` +
+		`"$defs":{"OASAddress":{"description":"Postal address","properties":{"city":{"type":"string"},"street":{"type":"string"}},"required":["city"],"type":"object"}},` +
+		`"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+		`"examples":[{"name":"root"}],` +
+		`"properties":{` +
+		`"address":{"anyOf":[{"$ref":"#/$defs/OASAddress"},{"type":"null"}]},` +
+		`"children":{"items":{"anyOf":[{"$ref":"#"},{"type":"null"}]},"maxItems":3,"minItems":1,"type":"array"},` +
+		`"data":{"contentEncoding":"base64","type":"string"},` +
+		`"home":{"$ref":"#/$defs/OASAddress","description":"Home address"},` +
+		`"kind":{"enum":["a","b",null],"type":["string","null"]},` +
+		`"name":{"maxLength":20,"minLength":1,"pattern":"^[a-z]+$","type":"string"},` +
+		`"status":{"enum":["A","B","C",null],"type":["string","null"]},` +
+		`"weight":{"examples":[0.5],"maximum":1,"minimum":0,"multipleOf":0.5,"type":"number"}` +
+		`},` +
+		`"required":["name"],"title":"Node","type":"object"}`
+	if got := mustJSON(t, doc); got != want {
+		t.Errorf("unexpected schema:\n%s\nexpected:\n%s", got, want)
+	}
+
+	if got := mustJSON(t, meta.JSONSchema[[]int]()); got != `{"$schema":"https://json-schema.org/draft/2020-12/schema","items":{"type":"integer"},"type":"array"}` {
+		t.Errorf("unexpected schema: %s", got)
+	}
+}
+
+type TaggedBase struct {
+	CreatedBy string `json:"createdBy" meta:"desc=Author,readOnly"`
+}
+
+type TaggedDTO struct {
+	TaggedBase
+	Email   string   `json:"email" meta:"desc='Contact e-mail, lowercase',format=email,required"`
+	Age     int      `json:"age" meta:"min=1,max=130,example=42"`
+	Code    string   `json:"code" meta:"pattern='^[A-Z]{2,3}$',minLength=2,maxLength=3"`
+	Tags    []string `json:"tags" meta:"minItems=1,maxItems=10"`
+	Kind    string   `json:"kind" meta:"enum=a|b"`
+	Ratio   float32  `json:"ratio" meta:"multipleOf=0.25,deprecated"`
+	Private string   `json:"private" meta:"writeOnly"`
+	Plain   string   `json:"plain"`
+}
+
+func TestMeta_Tags(t *testing.T) {
+	s := &TaggedDTO{}
+	meta.Describe(s, meta.Field(&s.Age, meta.Max(99), meta.Description("Age in years")))
+	data := meta.GetObjectMetadataAs[TaggedDTO]()
+
+	email := data.Fields["Email"]
+	if email.Description != "Contact e-mail, lowercase" || email.Format != "email" || !email.Required || email.JSONName != "email" {
+		t.Errorf("unexpected email metadata: %+v", email)
+	}
+	age := data.Fields["Age"]
+	if *age.Min != 1 || *age.Max != 99 || age.Example != 42 || age.Description != "Age in years" {
+		t.Errorf("expected tags merged with options, got %+v", age)
+	}
+	code := data.Fields["Code"]
+	if code.Pattern != "^[A-Z]{2,3}$" || *code.MinLength != 2 || *code.MaxLength != 3 {
+		t.Errorf("unexpected code metadata: %+v", code)
+	}
+	if tags := data.Fields["Tags"]; *tags.MinItems != 1 || *tags.MaxItems != 10 {
+		t.Errorf("unexpected tags metadata: %+v", tags)
+	}
+	if kind := data.Fields["Kind"]; len(kind.Enum) != 2 || kind.Enum[1] != "b" {
+		t.Errorf("unexpected kind metadata: %+v", kind)
+	}
+	if ratio := data.Fields["Ratio"]; *ratio.MultipleOf != 0.25 || !ratio.Deprecated {
+		t.Errorf("unexpected ratio metadata: %+v", ratio)
+	}
+	if !data.Fields["Private"].WriteOnly || !data.Fields["CreatedBy"].ReadOnly {
+		t.Error("expected flags from tags, including embedded fields")
+	}
+	if _, ok := data.Fields["Plain"]; ok {
+		t.Error("expected untagged field to have no metadata")
+	}
+	if len(data.Required) != 1 || data.Required[0] != "email" {
+		t.Errorf("unexpected required list: %v", data.Required)
+	}
+}
+
+func TestMeta_Tags_Errors(t *testing.T) {
+	cases := map[string]func(){
+		"unknown key": func() {
+			meta.Describe(&struct {
+				F string `meta:"size=1"`
+			}{})
+		},
+		"invalid number": func() {
+			meta.Describe(&struct {
+				F int `meta:"min=one"`
+			}{})
+		},
+		"flag with value": func() {
+			meta.Describe(&struct {
+				F int `meta:"required=true"`
+			}{})
+		},
+		"missing value": func() {
+			meta.Describe(&struct {
+				F int `meta:"desc"`
+			}{})
+		},
+		"unterminated quote": func() {
+			meta.Describe(&struct {
+				F int `meta:"desc='open"`
+			}{})
+		},
+		"invalid pattern": func() {
+			meta.Describe(&struct {
+				F string `meta:"pattern=[a-"`
+			}{})
+		},
+		"invalid example": func() {
+			meta.Describe(&struct {
+				F int `meta:"example=abc"`
+			}{})
+		},
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				err, ok := r.(error)
+				var tagErr *meta.TagError
+				if !ok || !errors.As(err, &tagErr) || tagErr.Field != "F" {
+					t.Errorf("expected *TagError panic, got %v", r)
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+type TagOnlyAddress struct {
+	City string `json:"city" meta:"required,minLength=2"`
+}
+
+type TagOnlyOrder struct {
+	ID      string          `json:"id" meta:"format=uuid"`
+	Address TagOnlyAddress  `json:"address"`
+	Billing *TagOnlyAddress `json:"billing"`
+}
+
+type InvalidTagOnly struct {
+	F string `meta:"minLength=-1"`
+}
+
+type InvalidTagParent struct {
+	Child InvalidTagOnly `json:"child"`
+}
+
+func TestMeta_TagsWithoutDescribe(t *testing.T) {
+	doc := meta.JSONSchema[TagOnlyOrder]()
+	got := mustJSON(t, doc)
+	for _, fragment := range []string{
+		`"id":{"format":"uuid","type":"string"}`,
+		`"TagOnlyAddress":{"properties":{"city":{"minLength":2,"type":"string"}},"required":["city"],"type":"object"}`,
+	} {
+		if !strings.Contains(got, fragment) {
+			t.Errorf("expected %s in %s", fragment, got)
+		}
+	}
+	if m := meta.GetObjectMetadataAs[TagOnlyAddress](); m == nil || !m.Fields["City"].Required {
+		t.Errorf("expected metadata read from tags, got %+v", m)
+	}
+	if meta.GetObjectMetadataAs[struct{ Plain string }]() != nil {
+		t.Error("expected no metadata for untagged types")
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		var tagErr *meta.TagError
+		if !ok || !errors.As(err, &tagErr) || tagErr.Field != "F" {
+			t.Errorf("expected *TagError panic, got %v", r)
+		}
+	}()
+	meta.JSONSchema[InvalidTagParent]()
+}
+
+type ValidatedDTO struct {
+	Name     string            `json:"name" meta:"required,minLength=2,maxLength=10"`
+	Email    string            `json:"email" meta:"format=email"`
+	Code     string            `json:"code" meta:"pattern=^[A-Z]+$"`
+	Kind     string            `json:"kind" meta:"enum=a|b"`
+	Age      int               `json:"age" meta:"min=18,max=130"`
+	Step     float64           `json:"step" meta:"multipleOf=0.5"`
+	Tags     []string          `json:"tags" meta:"minItems=1,maxItems=2"`
+	Active   bool              `json:"active"`
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels"`
+	Nickname *string           `json:"nickname"`
+	Hidden   string            `json:"-"`
+}
+
+type ValidatedBase struct {
+	Owner string  `json:"owner" meta:"required,minLength=3"`
+	Rate  float64 `json:"rate" meta:"multipleOf=0.5"`
+}
+
+type describedBase struct {
+	Tenant string `json:"tenant"`
+}
+
+type EmbeddedValidatedDTO struct {
+	ValidatedBase
+	describedBase
+	Name string `json:"name" meta:"required"`
+}
+
+type PointerEmbeddedDTO struct {
+	*ValidatedBase
+	Name string `json:"name"`
+}
+
+func TestMeta_ValidationSchema(t *testing.T) {
+	meta.Describe(&ValidatedDTO{})
+	schema := meta.ValidationSchema[ValidatedDTO]()
+
+	nickname := "neo"
+	valid := ValidatedDTO{
+		Name: "Ann", Email: "ann@example.com", Code: "AB", Kind: "a", Age: 30, Step: 1.5,
+		Tags: []string{"x"}, Active: true, Labels: map[string]string{"k": "v"}, Nickname: &nickname,
+	}
+	out, err := schema.Validate(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "Ann" || out.Age != 30 || !out.Active || out.Labels["k"] != "v" || out.Nickname == nil || *out.Nickname != "neo" {
+		t.Errorf("expected every field decoded, got %+v", out)
+	}
+
+	invalid := ValidatedDTO{Name: "A", Email: "nope", Code: "ab", Kind: "c", Age: 10, Step: 0.3, Tags: []string{"x", "y", "z"}}
+	_, err = schema.Validate(invalid)
+	var validationErr *validate.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	codes := map[string]string{}
+	for _, issue := range validationErr.Issues {
+		if _, ok := codes[issue.Path]; !ok {
+			codes[issue.Path] = issue.Code
+		}
+	}
+	for path, code := range map[string]string{
+		"name":  "string.min",
+		"email": "string.email",
+		"code":  "string.pattern",
+		"kind":  "text.oneof",
+		"age":   "number.min",
+		"step":  meta.CodeMultipleOf,
+		"tags":  "array.max",
+	} {
+		if codes[path] != code {
+			t.Errorf("%s: expected %s, got %q (%v)", path, code, codes[path], codes)
+		}
+	}
+
+	if _, err := schema.Validate([]byte(`{"email":"a@b.co"}`)); err == nil || !strings.Contains(err.Error(), "name: required") {
+		t.Errorf("expected required error, got %v", err)
+	}
+
+	base := &describedBase{}
+	meta.Describe(base, meta.Field(&base.Tenant, meta.Required()))
+	embeddedSchema := meta.ValidationSchema[EmbeddedValidatedDTO]()
+	embedded, err := embeddedSchema.Validate([]byte(`{"owner":"acme","tenant":"t1","name":"Ann","rate":1.5}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if embedded.Owner != "acme" || embedded.Tenant != "t1" || embedded.Rate != 1.5 || embedded.Name != "Ann" {
+		t.Errorf("expected promoted fields decoded, got %+v", embedded)
+	}
+	if _, err := embeddedSchema.Validate(embedded); err != nil {
+		t.Errorf("expected struct input with embedded fields to validate, got %v", err)
+	}
+
+	_, err = embeddedSchema.Validate([]byte(`{"owner":"x","name":"Ann","rate":0.3}`))
+	validationErr = nil
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	codes = map[string]string{}
+	for _, issue := range validationErr.Issues {
+		codes[issue.Path] = issue.Code
+	}
+	for path, code := range map[string]string{"owner": "string.min", "tenant": "text.required", "rate": meta.CodeMultipleOf} {
+		if codes[path] != code {
+			t.Errorf("%s: expected %s, got %q (%v)", path, code, codes[path], codes)
+		}
+	}
+
+	if _, err := meta.ValidationSchema[PointerEmbeddedDTO]().Validate([]byte(`{"owner":"acme","name":"Ann"}`)); err == nil || errors.As(err, &validationErr) {
+		t.Errorf("expected build error for a struct embedded by pointer, got %v", err)
+	}
+}
+
+type ExampleItem struct {
+	SKU   string `json:"sku" meta:"pattern=^[A-Z]{3}-\\d{4}$"`
+	Count uint   `json:"count" meta:"min=1,max=5"`
+}
+
+type ExampleOrder struct {
+	ID       string        `json:"id" meta:"format=uuid"`
+	Customer ValidatedDTO  `json:"customer"`
+	Items    []ExampleItem `json:"items" meta:"minItems=2"`
+	Note     string        `json:"note" meta:"example=leave at the door"`
+	Created  string        `json:"created" meta:"format=date-time"`
+	Status   EnumType      `json:"status"`
+	Parent   *ExampleOrder `json:"parent"`
+}
+
+func TestMeta_GenerateExample(t *testing.T) {
+	meta.Describe(&ValidatedDTO{})
+	meta.Describe(&ExampleItem{})
+	meta.Describe(&ExampleOrder{})
+
+	order := meta.GenerateExample[ExampleOrder](meta.WithSeed(7))
+	if !reflect.DeepEqual(order, meta.GenerateExample[ExampleOrder](meta.WithSeed(7))) {
+		t.Error("expected equal seeds to produce equal examples")
+	}
+	if reflect.DeepEqual(order, meta.GenerateExample[ExampleOrder](meta.WithSeed(8))) {
+		t.Error("expected different seeds to produce different examples")
+	}
+
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(order.ID) {
+		t.Errorf("expected uuid, got %q", order.ID)
+	}
+	if _, err := time.Parse(time.RFC3339, order.Created); err != nil {
+		t.Errorf("expected date-time, got %q", order.Created)
+	}
+	if order.Note != "leave at the door" {
+		t.Errorf("expected field example, got %q", order.Note)
+	}
+	if order.Status != "A" && order.Status != "B" && order.Status != "C" {
+		t.Errorf("expected enum value, got %q", order.Status)
+	}
+	if order.Parent != nil {
+		t.Errorf("expected recursive reference left empty, got %+v", order.Parent)
+	}
+	if len(order.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(order.Items))
+	}
+	for _, item := range order.Items {
+		if !regexp.MustCompile(`^[A-Z]{3}-\d{4}$`).MatchString(item.SKU) || item.Count < 1 || item.Count > 5 {
+			t.Errorf("expected constrained item, got %+v", item)
+		}
+	}
+
+	if _, err := meta.ValidationSchema[ValidatedDTO]().Validate(order.Customer); err != nil {
+		t.Errorf("expected generated customer to validate, got %v (%+v)", err, order.Customer)
+	}
+	if order.Customer.Hidden != "" || order.Customer.Nickname == nil || len(order.Customer.Labels) != 1 {
+		t.Errorf("expected nested fields filled, got %+v", order.Customer)
+	}
+}
+
+type BoundedExample struct {
+	Big   int64  `json:"big" meta:"min=0,max=9223372036854775807"`
+	Wide  uint64 `json:"wide" meta:"max=18446744073709551615,multipleOf=2"`
+	Small int8   `json:"small" meta:"min=100,max=1000"`
+	Below int16  `json:"below" meta:"min=-100000,max=-50000"`
+	Above uint8  `json:"above" meta:"min=300"`
+}
+
+type EmptyEnum string
+
+func (EmptyEnum) Values() []string { return []string{} }
+
+func TestMeta_GenerateExample_Bounds(t *testing.T) {
+	meta.Describe(&BoundedExample{})
+	for seed := range uint64(20) {
+		example := meta.GenerateExample[BoundedExample](meta.WithSeed(seed))
+		if example.Big < 0 || example.Wide%2 != 0 {
+			t.Errorf("expected values within wide bounds, got %+v", example)
+		}
+		if example.Small < 100 || example.Below != math.MinInt16 || example.Above != math.MaxUint8 {
+			t.Errorf("expected bounds clamped to the kind range, got %+v", example)
+		}
+	}
+
+	if got := meta.GenerateExample[EmptyEnum](); got == "" {
+		t.Error("expected a text example for an enum without values")
+	}
+	if got := meta.GenerateExample[any](); got != nil {
+		t.Errorf("expected nil interface, got %v", got)
+	}
+	if got := meta.GenerateExample[error](); got != nil {
+		t.Errorf("expected nil error, got %v", got)
+	}
+}
+
+type LocalizedDTO struct {
+	Name string `json:"name"`
+}
+
+func TestMeta_LocalizedText(t *testing.T) {
+	text := meta.Text{"en": "Name", "pt-BR": "Nome", "es": "Nombre"}
+	for locale, expected := range map[string]string{"pt-BR": "Nome", "es-AR": "Nombre", "fr": "Name", "": "Name", "en-US": "Name"} {
+		if got := text.Get(locale); got != expected {
+			t.Errorf("%q: expected %q, got %q", locale, expected, got)
+		}
+	}
+	if got := (meta.Text{"pt": "Olá"}).Get("en"); got != "" {
+		t.Errorf("expected no text without the default locale, got %q", got)
+	}
+
+	s := &LocalizedDTO{}
+	meta.Describe(s,
+		meta.Title(meta.Text{"en": "Person", "pt-BR": "Pessoa"}),
+		meta.Description("Plain description"),
+		meta.Field(&s.Name, meta.Description(text)),
+	)
+	m := meta.GetObjectMetadataAs[LocalizedDTO]()
+	if m.Title != "Person" || m.LocalizedTitle("pt-BR") != "Pessoa" || m.LocalizedDescription("pt-BR") != "Plain description" {
+		t.Errorf("unexpected object texts: %q %q %q", m.Title, m.LocalizedTitle("pt-BR"), m.LocalizedDescription("pt-BR"))
+	}
+	if field := m.Fields["Name"]; field.Description != "Name" || field.LocalizedDescription("pt-BR") != "Nome" {
+		t.Errorf("unexpected field texts: %q %q", field.Description, field.LocalizedDescription("pt-BR"))
+	}
+
+	doc := meta.JSONSchema[LocalizedDTO](meta.WithLocale("pt-BR"))
+	if got := mustJSON(t, doc); !strings.Contains(got, `"title":"Pessoa"`) || !strings.Contains(got, `"description":"Nome"`) {
+		t.Errorf("unexpected localized JSON Schema: %s", got)
+	}
+	if got := mustJSON(t, meta.JSONSchema[LocalizedDTO]()); !strings.Contains(got, `"title":"Person"`) {
+		t.Errorf("unexpected default JSON Schema: %s", got)
+	}
+
+	components := &oas.Components{}
+	meta.NewOpenAPIGenerator(components, meta.WithLocale("pt-BR")).Register(reflect.TypeFor[LocalizedDTO]())
+	if got := mustJSON(t, components); !strings.Contains(got, `"title":"Pessoa"`) || !strings.Contains(got, `"description":"Nome"`) {
+		t.Errorf("unexpected localized OpenAPI schema: %s", got)
+	}
+}
+
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+type AuditBase struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type Invoice struct {
+	AuditBase
+	Total  Money  `json:"total"`
+	Refund *Money `json:"refund"`
+}
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type Pair[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+func TestMeta_Composition(t *testing.T) {
+	meta.TypeOverride[Money]("string", meta.Pattern(`^\d+\.\d{2}$`), meta.Example("9.90"))
+	if override := meta.GetTypeOverride(reflect.TypeFor[Money]()); override == nil || override.SchemaType != "string" || override.Field.Pattern == "" {
+		t.Fatalf("unexpected override: %+v", override)
+	}
+
+	b := &AuditBase{}
+	meta.Describe(b, meta.Field(&b.CreatedBy, meta.Description("Author"), meta.Required()))
+	i := &Invoice{}
+	meta.Describe(i, meta.Field(&i.Total, meta.Description("Amount due")))
+
+	m := meta.GetObjectMetadataAs[Invoice]()
+	if len(m.Embedded) != 1 || m.Embedded[0] != reflect.TypeFor[AuditBase]() {
+		t.Errorf("expected embedded AuditBase, got %v", m.Embedded)
+	}
+
+	doc := mustJSON(t, meta.JSONSchema[Invoice]())
+	for _, fragment := range []string{
+		`"createdBy":{"description":"Author","type":"string"}`,
+		`"required":["createdBy"]`,
+		`"total":{"description":"Amount due","examples":["9.90"],"pattern":"^\\d+\\.\\d{2}$","type":"string"}`,
+		`"refund":{"examples":["9.90"],"pattern":"^\\d+\\.\\d{2}$","type":["string","null"]}`,
+	} {
+		if !strings.Contains(doc, fragment) {
+			t.Errorf("expected %s in %s", fragment, doc)
+		}
+	}
+	if strings.Contains(doc, "$defs") {
+		t.Errorf("expected overridden type inlined, got %s", doc)
+	}
+
+	components := &oas.Components{}
+	g := meta.NewOpenAPIGenerator(components)
+	g.Register(reflect.TypeFor[Invoice]())
+	if got := mustJSON(t, components); !strings.Contains(got, `"total":{"type":"string","description":"Amount due","pattern":"^\\d+\\.\\d{2}$","example":"9.90"}`) {
+		t.Errorf("unexpected OpenAPI override: %s", got)
+	}
+
+	for typ, expected := range map[reflect.Type]string{
+		reflect.TypeFor[Page[OASAddress]]():                  "#/components/schemas/PageOASAddress",
+		reflect.TypeFor[Page[[]*OASAddress]]():               "#/components/schemas/PageOASAddressList",
+		reflect.TypeFor[Pair[string, map[string]int]]():      "#/components/schemas/PairStringIntMap",
+		reflect.TypeFor[Page[Pair[int, Page[OASAddress]]]](): "#/components/schemas/PagePairIntPageOASAddress",
+	} {
+		if ref := g.Register(typ); ref != expected {
+			t.Errorf("%s: expected %s, got %s", typ, expected, ref)
+		}
+	}
+	if got := mustJSON(t, meta.JSONSchema[Pair[string, Page[OASAddress]]]()); !strings.Contains(got, `"$defs":{"OASAddress"`) || !strings.Contains(got, `"$ref":"#/$defs/PageOASAddress"`) {
+		t.Errorf("unexpected generic $defs: %s", got)
+	}
+}
+
+type Components struct {
+	Name string `json:"name"`
+}
+
+func TestMeta_ComponentNameCollisions(t *testing.T) {
+	type OASAddress struct {
+		Local bool `json:"local"`
+	}
+	types := []reflect.Type{
+		reflect.TypeFor[Components](),
+		reflect.TypeFor[oas.Components](),
+		reflect.TypeFor[Page[Components]](),
+		reflect.TypeFor[Page[oas.Components]](),
+		reflect.TypeFor[OASAddress](),
+	}
+	expected := []string{"Components", "TypesComponents", "PageComponents", "PageTypesComponents", "OASAddress"}
+
+	g := meta.NewOpenAPIGenerator(&oas.Components{})
+	for i, typ := range types {
+		if ref := g.Register(typ); ref != "#/components/schemas/"+expected[i] {
+			t.Errorf("%s: expected %s, got %s", typ, expected[i], ref)
+		}
+	}
+	if ref := g.Register(reflect.TypeFor[Page[oas.Components]]()); ref != "#/components/schemas/PageTypesComponents" {
+		t.Errorf("expected a registered type to keep its name, got %s", ref)
+	}
+
+	type Collisions struct {
+		Local      Components           `json:"local"`
+		Remote     oas.Components       `json:"remote"`
+		LocalPage  Page[Components]     `json:"localPage"`
+		RemotePage Page[oas.Components] `json:"remotePage"`
+		Address    OASAddress           `json:"address"`
+		Shadowed   *OASAddress          `json:"shadowed"`
+	}
+	doc := meta.JSONSchema[struct {
+		Collisions
+		Package OASAddressAlias `json:"package"`
+	}]()
+	defs, _ := doc["$defs"].(map[string]any)
+	for _, name := range []string{"Components", "TypesComponents", "PageComponents", "PageTypesComponents", "OASAddress", "MetaTestOASAddress"} {
+		if _, ok := defs[name]; !ok {
+			t.Errorf("expected $defs/%s in %v", name, mustJSON(t, doc))
+		}
+	}
+	for name := range defs {
+		if strings.ContainsAny(name, "/.[]~") {
+			t.Errorf("expected sanitized $defs name, got %q", name)
+		}
+	}
+}
+
+type OASAddressAlias = OASAddress
+
+type CreateInvoice struct {
+	Customer string `json:"customer"`
+}
+
+type InvoiceCreated struct {
+	ID string `json:"id"`
+}
+
+func TestMeta_Operations(t *testing.T) {
+	meta.OperationFor[*CreateInvoice, InvoiceCreated](
+		meta.Summary(meta.Text{"en": "Create an invoice", "pt-BR": "Criar uma fatura"}),
+		meta.Description("Issues a new invoice"),
+		meta.Throws[MyError](),
+		meta.Tags("invoices"),
+		meta.Auth("bearer", "invoices:write"),
+	)
+	meta.OperationFor[CreateInvoice, InvoiceCreated](meta.Tags("billing"))
+	meta.Operation("health", meta.Summary("Health check"), meta.Output[map[string]string](), meta.Deprecated())
+
+	op := meta.GetOperationFor[CreateInvoice]()
+	if op == nil || op != meta.GetOperation("CreateInvoice") || op != meta.GetOperationByType(reflect.TypeFor[*CreateInvoice]()) {
+		t.Fatalf("expected one operation by type and name, got %+v", op)
+	}
+	if op.Input != reflect.TypeFor[CreateInvoice]() || op.Output != reflect.TypeFor[InvoiceCreated]() {
+		t.Errorf("unexpected types: %v %v", op.Input, op.Output)
+	}
+	if op.Summary != "Create an invoice" || op.LocalizedSummary("pt-BR") != "Criar uma fatura" || op.Description != "Issues a new invoice" {
+		t.Errorf("unexpected texts: %+v", op)
+	}
+	if len(op.Throws) != 1 || op.Throws[0].ErrorType != reflect.TypeFor[MyError]() {
+		t.Errorf("unexpected throws: %+v", op.Throws)
+	}
+	if !reflect.DeepEqual(op.Tags, []string{"invoices", "billing"}) || len(op.Security) != 1 || op.Security[0].Scheme != "bearer" {
+		t.Errorf("unexpected tags or security: %v %v", op.Tags, op.Security)
+	}
+	if health := meta.GetOperation("health"); health == nil || !health.Deprecated || health.Input != nil || health.Output == nil {
+		t.Errorf("unexpected named operation: %+v", health)
+	}
+	var names []string
+	for _, operation := range meta.Operations() {
+		names = append(names, operation.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"CreateInvoice", "health"}) {
+		t.Errorf("unexpected operations: %v", names)
+	}
+
+	operation := &oas.Operation{}
+	components := &oas.Components{}
+	meta.NewOpenAPIGenerator(components, meta.WithLocale("pt-BR")).Operation(op)(operation)
+	got := mustJSON(t, operation)
+	for _, fragment := range []string{
+		`"operationId":"CreateInvoice"`,
+		`"summary":"Criar uma fatura"`,
+		`"tags":["invoices","billing"]`,
+		`"security":[{"bearer":["invoices:write"]}]`,
+		`"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/CreateInvoice"}}},"required":true}`,
+		`"200":{"description":"OK","content":{"application/json":{"schema":{"$ref":"#/components/schemas/InvoiceCreated"}}}}`,
+	} {
+		if !strings.Contains(got, fragment) {
+			t.Errorf("expected %s in %s", fragment, got)
+		}
+	}
+
+	assertPanic := func(t *testing.T, f func()) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Did not panic")
+			}
+		}()
+		f()
+	}
+	type CreateInvoice struct{ Draft bool }
+	t.Run("Same name, other type", func(t *testing.T) {
+		assertPanic(t, func() { meta.OperationFor[CreateInvoice, InvoiceCreated]() })
+	})
+	meta.Operation("ConflictingQuery", meta.Summary("Named first"))
+	t.Run("Same name as named operation", func(t *testing.T) {
+		assertPanic(t, func() { meta.OperationFor[ConflictingQuery, InvoiceCreated]() })
+	})
+	if meta.GetOperation("CreateInvoice") != op || meta.GetOperation("ConflictingQuery").Summary != "Named first" {
+		t.Error("expected conflicting registrations to keep the existing operations")
+	}
+	if meta.GetOperationFor[CreateInvoice]() != nil || meta.GetOperationFor[ConflictingQuery]() != nil {
+		t.Error("expected conflicting types to stay unregistered")
+	}
+}
+
+type ConflictingQuery struct{}
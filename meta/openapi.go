@@ -0,0 +1,296 @@
+package meta
+
+import (
+	"reflect"
+
+	"github.com/leandroluk/gox/oas"
+	"github.com/leandroluk/gox/oas/enums"
+)
+
+// OpenAPIGenerator converts Go types into OpenAPI schemas using the registered
+// ObjectMetadata. Named struct types are added once to the components and referenced
+// with $ref, so shared and recursive types are reused.
+type OpenAPIGenerator struct {
+	components *oas.Components
+	locale     string
+	names      map[reflect.Type]string
+	taken      map[string]reflect.Type
+}
+
+// NewOpenAPIGenerator creates a generator that registers schemas in components.
+// Titles and descriptions are exported in the locale set with WithLocale.
+func NewOpenAPIGenerator(components *oas.Components, options ...SchemaOption) *OpenAPIGenerator {
+	return &OpenAPIGenerator{
+		components: components,
+		locale:     applySchemaOptions(options).Locale,
+		names:      make(map[reflect.Type]string),
+		taken:      make(map[string]reflect.Type),
+	}
+}
+
+// OpenAPISchema returns a builder for the schema of T, for use with oas callbacks
+// such as MediaType.Schema.
+func OpenAPISchema[T any](g *OpenAPIGenerator) func(s *oas.Schema) {
+	return g.Schema(reflect.TypeFor[T]())
+}
+
+// Schema returns a builder for the schema of t.
+func (g *OpenAPIGenerator) Schema(t reflect.Type) func(s *oas.Schema) {
+	return func(s *oas.Schema) {
+		g.build(s, t, nil)
+	}
+}
+
+// Register adds the struct type t (or pointer to it) to the components and returns
+// its $ref.
+func (g *OpenAPIGenerator) Register(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return "#/components/schemas/" + g.component(t)
+}
+
+// component registers the struct type t once and returns its component name.
+func (g *OpenAPIGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := uniqueComponentName(t, g.taken)
+	g.names[t] = name
+	g.taken[name] = t
+
+	m := GetObjectMetadataByType(t)
+	g.components.Schema(name, func(s *oas.Schema) {
+		var fields map[string]*FieldMetadata
+		if m != nil {
+			fields = m.Fields
+			applyObjectMetadata(s, m, g.locale)
+		}
+		g.object(s, t, fields)
+	})
+	return name
+}
+
+// Operation returns a builder documenting an oas operation from m: its id, summary,
+// description, tags, security, the JSON request body of Input and the JSON "200"
+// response of Output. Errors in m.Throws are left to the HTTP adapter, which knows
+// their status codes.
+func (g *OpenAPIGenerator) Operation(m *OperationMetadata) func(o *oas.Operation) {
+	return func(o *oas.Operation) {
+		o.OperationId(m.Name)
+		if summary := m.LocalizedSummary(g.locale); summary != "" {
+			o.Summary(summary)
+		}
+		if description := m.LocalizedDescription(g.locale); description != "" {
+			o.Description(description)
+		}
+		if len(m.Tags) > 0 {
+			o.Tags(m.Tags...)
+		}
+		if m.Deprecated {
+			o.Deprecated(true)
+		}
+		if m.ExternalDocs != nil {
+			o.ExternalDoc(m.ExternalDocs.URL, func(e *oas.ExternalDocs) {
+				if m.ExternalDocs.Description != "" {
+					e.Description(m.ExternalDocs.Description)
+				}
+			})
+		}
+		for _, requirement := range m.Security {
+			o.Security(requirement.Scheme, requirement.Scopes...)
+		}
+		if m.Input != nil {
+			o.RequestBody(func(r *oas.RequestBody) {
+				r.Required(true).Json(func(mt *oas.MediaType) { mt.Schema(g.Schema(m.Input)) })
+			})
+		}
+		if m.Output != nil {
+			o.Response("200", func(r *oas.Response) {
+				r.Description("OK").Json(func(mt *oas.MediaType) { mt.Schema(g.Schema(m.Output)) })
+			})
+		}
+	}
+}
+
+// build fills s with the schema of t. nested holds metadata registered for the
+// fields of t through a parent struct; when present, t is inlined instead of
+// referenced. Pointers are nullable, with references wrapped in allOf.
+func (g *OpenAPIGenerator) build(s *oas.Schema, t reflect.Type, nested map[string]*FieldMetadata) {
+	if t.Kind() == reflect.Pointer {
+		s.Nullable()
+		if isReference(t.Elem(), nested) {
+			s.AllOf(func(ref *oas.Schema) { g.build(ref, t.Elem(), nil) })
+			return
+		}
+		g.build(s, t.Elem(), nested)
+		return
+	}
+	if override := GetTypeOverride(t); override != nil {
+		s.Type(enums.SchemaType(override.SchemaType))
+		applyFieldMetadata(s, override.Field, g.locale)
+		return
+	}
+	if values := enumValues(t); values != nil {
+		s.String().Enum(anySlice(values)...)
+		return
+	}
+
+	switch {
+	case t == timeType:
+		s.String().Format("date-time")
+		return
+	case t == rawMessageType:
+		return
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		s.String().Format("byte")
+		return
+	case isTextType(t):
+		s.String()
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s.String()
+	case reflect.Bool:
+		s.Boolean()
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		s.Integer().Format("int32")
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		s.Integer().Format("int64")
+	case reflect.Float32:
+		s.Number().Format("float")
+	case reflect.Float64:
+		s.Number().Format("double")
+	case reflect.Slice, reflect.Array:
+		s.Array().Items(func(item *oas.Schema) { g.build(item, t.Elem(), nil) })
+	case reflect.Map:
+		item := &oas.Schema{}
+		g.build(item, t.Elem(), nil)
+		s.Object().AdditionalProperties(item)
+	case reflect.Struct:
+		if nested == nil && componentName(t) != "" {
+			s.Ref("#/components/schemas/" + g.component(t))
+			return
+		}
+		g.object(s, t, mergeFields(objectFields(t), nested))
+	}
+}
+
+// object fills s with the properties of the struct type t.
+func (g *OpenAPIGenerator) object(s *oas.Schema, t reflect.Type, fields map[string]*FieldMetadata) {
+	s.Object()
+	for _, field := range schemaFields(t, fields) {
+		build := func(property *oas.Schema) { g.field(property, field) }
+		if field.Metadata != nil && field.Metadata.Required {
+			s.Required(field.Name, build)
+		} else {
+			s.Property(field.Name, build)
+		}
+	}
+}
+
+// field fills s with the schema of a struct field and its metadata. Annotated
+// references are wrapped in allOf, as $ref siblings are ignored.
+func (g *OpenAPIGenerator) field(s *oas.Schema, field schemaField) {
+	if field.Metadata != nil && field.Type.Kind() != reflect.Pointer && isReference(field.Type, field.Nested) {
+		s.AllOf(func(ref *oas.Schema) { g.build(ref, field.Type, nil) })
+	} else {
+		g.build(s, field.Type, field.Nested)
+	}
+	if field.Metadata != nil {
+		applyFieldMetadata(s, field.Metadata, g.locale)
+	}
+}
+
+// isReference reports whether the schema of t is a $ref to a component.
+func isReference(t reflect.Type, nested map[string]*FieldMetadata) bool {
+	return t.Kind() == reflect.Struct && t != timeType && nested == nil &&
+		componentName(t) != "" && enumValues(t) == nil && !isTextType(t) && GetTypeOverride(t) == nil
+}
+
+func applyObjectMetadata(s *oas.Schema, m *ObjectMetadata, locale string) {
+	if title := m.LocalizedTitle(locale); title != "" {
+		s.Title(title)
+	}
+	if description := m.LocalizedDescription(locale); description != "" {
+		s.Description(description)
+	}
+	if m.Deprecated {
+		s.Deprecated(true)
+	}
+	if m.ExternalDocs != nil {
+		applyExternalDocs(s, m.ExternalDocs)
+	}
+	if m.Example != nil {
+		s.Example(m.Example)
+	}
+}
+
+func applyFieldMetadata(s *oas.Schema, fm *FieldMetadata, locale string) {
+	if description := fm.LocalizedDescription(locale); description != "" {
+		s.Description(description)
+	}
+	if fm.Example != nil {
+		s.Example(fm.Example)
+	}
+	if fm.Format != "" {
+		s.Format(fm.Format)
+	}
+	if fm.ReadOnly {
+		s.ReadOnly(true)
+	}
+	if fm.WriteOnly {
+		s.WriteOnly(true)
+	}
+	if fm.Deprecated {
+		s.Deprecated(true)
+	}
+	if len(fm.Enum) > 0 {
+		s.Enum(anySlice(fm.Enum)...)
+	}
+	if fm.ExternalDocs != nil {
+		applyExternalDocs(s, fm.ExternalDocs)
+	}
+	if fm.Min != nil {
+		s.Minimum(*fm.Min)
+	}
+	if fm.Max != nil {
+		s.Maximum(*fm.Max)
+	}
+	if fm.MultipleOf != nil {
+		s.MultipleOf(*fm.MultipleOf)
+	}
+	if fm.MinLength != nil {
+		s.MinLength(int64(*fm.MinLength))
+	}
+	if fm.MaxLength != nil {
+		s.MaxLength(int64(*fm.MaxLength))
+	}
+	if fm.Pattern != "" {
+		s.Pattern(fm.Pattern)
+	}
+	if fm.MinItems != nil {
+		s.MinItems(int64(*fm.MinItems))
+	}
+	if fm.MaxItems != nil {
+		s.MaxItems(int64(*fm.MaxItems))
+	}
+}
+
+func applyExternalDocs(s *oas.Schema, docs *ExternalDocs) {
+	s.ExternalDoc(docs.URL, func(e *oas.ExternalDocs) {
+		if docs.Description != "" {
+			e.Description(docs.Description)
+		}
+	})
+}
+
+func anySlice(values []string) []any {
+	out := make([]any, len(values))
+	for i, value := range values {
+		out[i] = value
+	}
+	return out
+}
@@ -0,0 +1,181 @@
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var (
+	operationsByName = make(map[string]*OperationMetadata)
+	operationsByType = make(map[reflect.Type]*OperationMetadata)
+)
+
+// Operation initializes or updates the metadata of the operation registered under
+// name, such as an HTTP endpoint:
+//
+//	meta.Operation("getUser",
+//		meta.Summary("Get a user"),
+//		meta.Input[GetUserRequest](), meta.Output[User](),
+//		meta.Throws[NotFoundError](), meta.Tags("users"), meta.Auth("bearer"),
+//	)
+func Operation(name string, options ...OperationOption) {
+	if name == "" {
+		panic("meta: operation name is empty")
+	}
+	registryMutex.Lock()
+	metadata, exists := operationsByName[name]
+	if !exists {
+		metadata = &OperationMetadata{Name: name}
+		operationsByName[name] = metadata
+	}
+	registryMutex.Unlock()
+
+	applyOperationOptions(metadata, options)
+}
+
+// OperationFor initializes or updates the metadata of the operation handling the
+// message type TMessage with the result TResult, such as a cqrs command or query.
+// It is keyed by TMessage without pointer, as cqrs does, and also registered under
+// the name of TMessage. It panics if that name is already taken by an operation
+// registered with Operation or by another message type with the same name.
+func OperationFor[TMessage any, TResult any](options ...OperationOption) {
+	messageType := indirectType(reflect.TypeFor[TMessage]())
+	name := componentName(messageType)
+	if name == "" {
+		name = messageType.String()
+	}
+
+	registryMutex.Lock()
+	metadata, exists := operationsByType[messageType]
+	if !exists {
+		if existing, taken := operationsByName[name]; taken {
+			registryMutex.Unlock()
+			if existing.Input != nil {
+				panic(fmt.Sprintf("meta: operation %q is already registered for %v, cannot register %v", name, existing.Input, messageType))
+			}
+			panic(fmt.Sprintf("meta: operation %q is already registered, cannot register %v", name, messageType))
+		}
+		metadata = &OperationMetadata{Name: name}
+		operationsByType[messageType] = metadata
+		operationsByName[name] = metadata
+	}
+	metadata.Input = messageType
+	metadata.Output = indirectType(reflect.TypeFor[TResult]())
+	registryMutex.Unlock()
+
+	applyOperationOptions(metadata, options)
+}
+
+// GetOperation retrieves the metadata of the operation registered under name,
+// including operations registered by message type under the name of the type.
+func GetOperation(name string) *OperationMetadata {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return operationsByName[name]
+}
+
+// GetOperationFor retrieves the metadata of the operation handling TMessage.
+func GetOperationFor[TMessage any]() *OperationMetadata {
+	return GetOperationByType(reflect.TypeFor[TMessage]())
+}
+
+// GetOperationByType retrieves the metadata of the operation handling messages of
+// type messageType (or pointer to it).
+func GetOperationByType(messageType reflect.Type) *OperationMetadata {
+	if messageType == nil {
+		return nil
+	}
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return operationsByType[indirectType(messageType)]
+}
+
+// Operations returns every registered operation, sorted by name.
+func Operations() []*OperationMetadata {
+	registryMutex.RLock()
+	out := make([]*OperationMetadata, 0, len(operationsByName))
+	for _, metadata := range operationsByName {
+		out = append(out, metadata)
+	}
+	registryMutex.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// LocalizedSummary returns the summary of the operation in locale.
+func (m *OperationMetadata) LocalizedSummary(locale string) string {
+	if m.Summaries != nil {
+		return m.Summaries.Get(locale)
+	}
+	return m.Summary
+}
+
+// LocalizedDescription returns the description of the operation in locale.
+func (m *OperationMetadata) LocalizedDescription(locale string) string {
+	if m.Descriptions != nil {
+		return m.Descriptions.Get(locale)
+	}
+	return m.Description
+}
+
+func applyOperationOptions(metadata *OperationMetadata, options []OperationOption) {
+	for _, option := range options {
+		if option != nil {
+			option.applyToOperation(metadata)
+		}
+	}
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// Summary sets a short summary of an operation, either a string or a Text keyed by
+// locale.
+type summaryDecorator struct {
+	Text      string
+	Localized Text
+}
+
+func Summary[S string | Text](text S) summaryDecorator {
+	value, localized := textOf(text)
+	return summaryDecorator{Text: value, Localized: localized}
+}
+func (d summaryDecorator) applyToOperation(m *OperationMetadata) {
+	m.Summary, m.Summaries = d.Text, d.Localized
+}
+
+// Tags groups an operation, e.g. by resource.
+type tagsDecorator []string
+
+func Tags(tags ...string) tagsDecorator { return tagsDecorator(tags) }
+func (d tagsDecorator) applyToOperation(m *OperationMetadata) {
+	m.Tags = append(m.Tags, d...)
+}
+
+// Auth requires the authentication scheme, with optional scopes, for an operation.
+type authDecorator SecurityRequirement
+
+func Auth(scheme string, scopes ...string) authDecorator {
+	return authDecorator{Scheme: scheme, Scopes: scopes}
+}
+func (d authDecorator) applyToOperation(m *OperationMetadata) {
+	m.Security = append(m.Security, SecurityRequirement(d))
+}
+
+// Input sets the request type of an operation.
+type inputDecorator struct{ Type reflect.Type }
+
+func Input[T any]() inputDecorator                             { return inputDecorator{indirectType(reflect.TypeFor[T]())} }
+func (d inputDecorator) applyToOperation(m *OperationMetadata) { m.Input = d.Type }
+
+// Output sets the response type of an operation.
+type outputDecorator struct{ Type reflect.Type }
+
+func Output[T any]() outputDecorator                            { return outputDecorator{indirectType(reflect.TypeFor[T]())} }
+func (d outputDecorator) applyToOperation(m *OperationMetadata) { m.Output = d.Type }
@@ -0,0 +1,59 @@
+package meta
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var typeOverrides = make(map[reflect.Type]*TypeOverrideMetadata)
+
+// TypeOverrideMetadata documents a Go type as another schema type, such as a Money
+// struct encoded as a decimal string.
+type TypeOverrideMetadata struct {
+	Type reflect.Type
+	// SchemaType is the JSON type of the encoded value: "string", "integer", "number",
+	// "boolean", "object" or "array".
+	SchemaType string
+	// Field holds the format, pattern, example and constraints of every value of Type.
+	Field *FieldMetadata
+}
+
+var schemaTypes = map[string]bool{
+	"string": true, "integer": true, "number": true, "boolean": true, "object": true, "array": true,
+}
+
+// TypeOverride registers, for every field and value of type T, the schema type and
+// field options used by the exporters instead of reflecting on T. Options of the
+// field itself are applied on top. Registering T again replaces its override.
+//
+//	meta.TypeOverride[Money]("string", meta.Pattern(`^\d+\.\d{2}$`), meta.Example("9.90"))
+func TypeOverride[T any](schemaType string, options ...FieldOption) {
+	if !schemaTypes[schemaType] {
+		panic(fmt.Sprintf("meta: invalid schema type %q in TypeOverride", schemaType))
+	}
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	field := &FieldMetadata{Type: t}
+	for _, option := range options {
+		if option != nil {
+			option.applyToField(field)
+		}
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	typeOverrides[t] = &TypeOverrideMetadata{Type: t, SchemaType: schemaType, Field: field}
+}
+
+// GetTypeOverride returns the override registered for t, or nil.
+func GetTypeOverride(t reflect.Type) *TypeOverrideMetadata {
+	if t == nil {
+		return nil
+	}
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return typeOverrides[t]
+}
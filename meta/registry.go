@@ -10,19 +10,13 @@ import (
 var (
 	registryMutex  sync.RWMutex
 	structRegistry = make(map[reflect.Type]*ObjectMetadata)
+	// untaggedTypes caches the structs looked up without Describe nor `meta` tags.
+	untaggedTypes = make(map[reflect.Type]bool)
 )
 
 // GetObjectMetadataAs retrieves metadata for the type T using Generics.
 func GetObjectMetadataAs[T any]() *ObjectMetadata {
-	structType := reflect.TypeFor[T]()
-	if structType.Kind() == reflect.Pointer {
-		structType = structType.Elem()
-	}
-
-	registryMutex.RLock()
-	defer registryMutex.RUnlock()
-
-	return structRegistry[structType]
+	return GetObjectMetadataByType(reflect.TypeFor[T]())
 }
 
 // GetObjectMetadataOf retrieves metadata based on the instance's type.
@@ -30,32 +24,59 @@ func GetObjectMetadataOf(structInstance any) *ObjectMetadata {
 	if structInstance == nil {
 		return nil
 	}
-	structType := reflect.TypeOf(structInstance)
+	return GetObjectMetadataByType(reflect.TypeOf(structInstance))
+}
+
+// GetObjectMetadataByType retrieves metadata for a specific reflect.Type. Structs
+// never passed to Describe are registered from their `meta` tags on first lookup,
+// so tags alone describe a type; an invalid tag panics with a *TagError.
+func GetObjectMetadataByType(structType reflect.Type) *ObjectMetadata {
+	if structType == nil {
+		return nil
+	}
 	if structType.Kind() == reflect.Pointer {
 		structType = structType.Elem()
 	}
 	if structType.Kind() != reflect.Struct {
 		return nil
 	}
+
 	registryMutex.RLock()
-	defer registryMutex.RUnlock()
-	return structRegistry[structType]
-}
+	metadata, exists := structRegistry[structType]
+	untagged := untaggedTypes[structType]
+	registryMutex.RUnlock()
+	if exists || untagged {
+		return metadata
+	}
 
-// GetObjectMetadataByType retrieves metadata for a specific reflect.Type.
-func GetObjectMetadataByType(structType reflect.Type) *ObjectMetadata {
-	if structType == nil {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if metadata, exists := structRegistry[structType]; exists {
+		return metadata
+	}
+	metadata = newObjectMetadata(structType)
+	if err := applyTags(metadata, structType); err != nil {
+		panic(err)
+	}
+	if len(metadata.Fields) == 0 {
+		untaggedTypes[structType] = true
 		return nil
 	}
-	if structType.Kind() == reflect.Pointer {
-		structType = structType.Elem()
+	structRegistry[structType] = metadata
+	return metadata
+}
+
+func newObjectMetadata(structType reflect.Type) *ObjectMetadata {
+	return &ObjectMetadata{
+		Fields:   make(map[string]*FieldMetadata),
+		Type:     structType,
+		Embedded: embeddedTypes(structType),
 	}
-	registryMutex.RLock()
-	defer registryMutex.RUnlock()
-	return structRegistry[structType]
 }
 
-// Describe initializes or updates metadata for a struct pointer.
+// Describe initializes or updates metadata for a struct pointer. The first call for
+// a type reads the `meta` tags of its fields, unless a lookup already did; options
+// are applied on top of them. An invalid tag panics with a *TagError.
 func Describe(target any, options ...ObjectOption) {
 	if target == nil {
 		panic("meta: target is nil in Describe")
@@ -71,11 +92,13 @@ func Describe(target any, options ...ObjectOption) {
 	registryMutex.Lock()
 	metadata, exists := structRegistry[structType]
 	if !exists {
-		metadata = &ObjectMetadata{
-			Fields: make(map[string]*FieldMetadata),
-			Type:   structType,
+		metadata = newObjectMetadata(structType)
+		if err := applyTags(metadata, structType); err != nil {
+			registryMutex.Unlock()
+			panic(err)
 		}
 		structRegistry[structType] = metadata
+		delete(untaggedTypes, structType)
 	}
 	registryMutex.Unlock()
 
@@ -151,3 +174,22 @@ func resolveFieldNameRecursive(
 	}
 	return "", "", nil
 }
+
+// embeddedTypes returns the struct types embedded in t without a json name.
+func embeddedTypes(t reflect.Type) []reflect.Type {
+	var out []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous || strings.Split(field.Tag.Get("json"), ",")[0] != "" {
+			continue
+		}
+		embedded := field.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if embedded.Kind() == reflect.Struct {
+			out = append(out, embedded)
+		}
+	}
+	return out
+}
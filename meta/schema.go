@@ -0,0 +1,284 @@
+package meta
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	enumerableType    = reflect.TypeFor[Enumerable]()
+)
+
+// schemaField is a struct field as encoded by encoding/json, with the metadata
+// registered for it.
+type schemaField struct {
+	Name     string
+	Type     reflect.Type
+	Metadata *FieldMetadata
+	// Nested holds the metadata registered through the parent for the fields of a
+	// nested struct (e.g. "Address.City"), keyed relative to the nested struct.
+	Nested map[string]*FieldMetadata
+}
+
+// schemaFields lists the JSON fields of the struct type t in declaration order.
+// Embedded structs without a json name are flattened like encoding/json does, and
+// fields holds the metadata keyed by Go field path, overlaying the metadata
+// registered for the embedded types.
+func schemaFields(t reflect.Type, fields map[string]*FieldMetadata) []schemaField {
+	var out []schemaField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" && !strings.HasPrefix(tag, "-,") {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				out = append(out, schemaFields(embedded, mergeFields(objectFields(embedded), fields))...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		out = append(out, schemaField{
+			Name:     name,
+			Type:     field.Type,
+			Metadata: fields[field.Name],
+			Nested:   nestedFields(fields, field.Name),
+		})
+	}
+	return out
+}
+
+// nestedFields returns the entries of fields under the prefix "name.", with the
+// prefix removed, or nil if there are none.
+func nestedFields(fields map[string]*FieldMetadata, name string) map[string]*FieldMetadata {
+	var out map[string]*FieldMetadata
+	prefix := name + "."
+	for key, value := range fields {
+		if rest, ok := strings.CutPrefix(key, prefix); ok {
+			if out == nil {
+				out = make(map[string]*FieldMetadata)
+			}
+			out[rest] = value
+		}
+	}
+	return out
+}
+
+// mergeFields returns the metadata of a struct type overlaid with the metadata
+// registered through a parent.
+func mergeFields(own, overrides map[string]*FieldMetadata) map[string]*FieldMetadata {
+	out := make(map[string]*FieldMetadata, len(own)+len(overrides))
+	for key, value := range own {
+		out[key] = value
+	}
+	for key, value := range overrides {
+		out[key] = value
+	}
+	return out
+}
+
+// objectFields returns the field metadata registered for the struct type t.
+func objectFields(t reflect.Type) map[string]*FieldMetadata {
+	if m := GetObjectMetadataByType(t); m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+// enumValues returns the values of t if it implements Enumerable.
+func enumValues(t reflect.Type) []string {
+	if t.Implements(enumerableType) {
+		return reflect.New(t).Elem().Interface().(Enumerable).Values()
+	}
+	return nil
+}
+
+// isTextType reports whether t is encoded as a JSON string through
+// encoding.TextMarshaler.
+func isTextType(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// componentName returns the name under which the struct type t is registered as a
+// reusable schema, or "" for anonymous types. Generic instantiations are named
+// after their type arguments, without package paths: Page[pkg.User] is "PageUser"
+// and Pair[string, []pkg.User] is "PairStringUserList".
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if !strings.Contains(name, "[") {
+		return name
+	}
+	return identifier(readableTypeName(name, 0))
+}
+
+// uniqueComponentName returns the component name of t, or when another type in taken
+// already uses it, the first free name qualified with package path segments from
+// the last one: type arguments first, then t itself. other.User becomes "OtherUser"
+// and Page[other.User] "PageOtherUser". Names only depend on the types involved.
+func uniqueComponentName(t reflect.Type, taken map[string]reflect.Type) string {
+	free := func(name string) bool {
+		other, ok := taken[name]
+		return !ok || other == t
+	}
+	name := componentName(t)
+	if free(name) {
+		return name
+	}
+
+	base, args := t.Name(), ""
+	if i := strings.IndexByte(base, '['); i >= 0 {
+		base, args = base[:i], base[i+1:closingBracket(base, i)]
+	}
+	for depth := 1; depth <= strings.Count(t.String(), "/")+1; depth++ {
+		qualifiedArgs := ""
+		for _, arg := range splitTypeArgs(args) {
+			qualifiedArgs += readableTypeName(arg, depth)
+		}
+		for _, candidate := range []string{
+			identifier(upperFirst(base) + qualifiedArgs),
+			identifier(packagePrefix(t.PkgPath(), depth) + upperFirst(base) + qualifiedArgs),
+		} {
+			if free(candidate) {
+				return candidate
+			}
+		}
+	}
+	// Types with the same path, such as types declared in different functions.
+	for i := 2; ; i++ {
+		if candidate := fmt.Sprintf("%s%d", name, i); free(candidate) {
+			return candidate
+		}
+	}
+}
+
+// identifier keeps the letters, digits and underscores of name.
+func identifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+// packagePrefix returns the last depth segments of the package path, each turned
+// into a capitalized word: "example.com/app/http_api" at depth 2 is "AppHttpApi".
+func packagePrefix(path string, depth int) string {
+	segments := strings.Split(path, "/")
+	if depth < len(segments) {
+		segments = segments[len(segments)-depth:]
+	}
+	var out strings.Builder
+	for _, segment := range segments {
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			out.WriteString(upperFirst(word))
+		}
+	}
+	return out.String()
+}
+
+func upperFirst(s string) string {
+	name := []rune(s)
+	if len(name) > 0 {
+		name[0] = unicode.ToUpper(name[0])
+	}
+	return string(name)
+}
+
+// readableTypeName turns a type string as printed by reflect into an identifier,
+// prefixing named types with depth segments of their package path.
+func readableTypeName(s string, depth int) string {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "interface {}" || s == "any":
+		return "Any"
+	case strings.HasPrefix(s, "*"):
+		return readableTypeName(s[1:], depth)
+	case strings.HasPrefix(s, "[]"):
+		return readableTypeName(s[2:], depth) + "List"
+	case strings.HasPrefix(s, "["):
+		return readableTypeName(s[closingBracket(s, 0)+1:], depth) + "List"
+	case strings.HasPrefix(s, "map["):
+		return readableTypeName(s[closingBracket(s, 3)+1:], depth) + "Map"
+	}
+
+	base, args := s, ""
+	if i := strings.IndexByte(s, '['); i >= 0 {
+		base, args = s[:i], s[i+1:closingBracket(s, i)]
+	}
+	path := ""
+	if dot := strings.LastIndex(base, "."); dot > strings.LastIndex(base, "/") {
+		path, base = base[:dot], base[dot+1:]
+	}
+	out := upperFirst(base)
+	if depth > 0 && path != "" {
+		out = packagePrefix(path, depth) + out
+	}
+	for _, arg := range splitTypeArgs(args) {
+		out += readableTypeName(arg, depth)
+	}
+	return out
+}
+
+// closingBracket returns the index of the bracket closing the one at open, or the
+// last index of s if it is unbalanced.
+func closingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s) - 1
+}
+
+// splitTypeArgs splits a type argument list on its top-level commas.
+func splitTypeArgs(s string) []string {
+	var out []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
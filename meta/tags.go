@@ -0,0 +1,224 @@
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TagError reports an invalid `meta` struct tag found while registering a type.
+type TagError struct {
+	Type  reflect.Type
+	Field string
+	Tag   string
+	Err   error
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("meta: %s.%s: invalid meta tag %q: %v", e.Type, e.Field, e.Tag, e.Err)
+}
+
+func (e *TagError) Unwrap() error {
+	return e.Err
+}
+
+// tagOptions maps the keys of a `meta` tag to field options. Flags take no value.
+var tagOptions = map[string]func(value string, fieldType reflect.Type) (FieldOption, error){
+	"desc":       func(v string, _ reflect.Type) (FieldOption, error) { return Description(v), nil },
+	"format":     func(v string, _ reflect.Type) (FieldOption, error) { return Format(v), nil },
+	"pattern":    parsePatternOption,
+	"min":        floatOption(Min),
+	"max":        floatOption(Max),
+	"multipleOf": floatOption(MultipleOf),
+	"minLength":  intOption(MinLength),
+	"maxLength":  intOption(MaxLength),
+	"minItems":   intOption(MinItems),
+	"maxItems":   intOption(MaxItems),
+	"enum":       func(v string, _ reflect.Type) (FieldOption, error) { return enumDecorator(strings.Split(v, "|")), nil },
+	"example":    parseExampleOption,
+}
+
+var tagFlags = map[string]FieldOption{
+	"required":   Required(),
+	"readOnly":   ReadOnly(),
+	"writeOnly":  WriteOnly(),
+	"deprecated": Deprecated(),
+}
+
+// enumDecorator sets the allowed values of a field declared in a `meta` tag.
+type enumDecorator []string
+
+func (d enumDecorator) applyToField(m *FieldMetadata) { m.Enum = d }
+
+// applyTags registers the metadata declared in the `meta` tags of the fields of the
+// struct type t, including fields promoted from embedded structs.
+func applyTags(m *ObjectMetadata, t reflect.Type) error {
+	return applyTagsRecursive(m, t, t)
+}
+
+func applyTagsRecursive(m *ObjectMetadata, root, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		if field.Anonymous && jsonName == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := applyTagsRecursive(m, root, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		tag, ok := field.Tag.Lookup("meta")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		options, err := parseTag(tag, field.Type)
+		if err != nil {
+			return &TagError{Type: root, Field: field.Name, Tag: tag, Err: err}
+		}
+		if jsonName == "" || jsonName == "-" {
+			jsonName = field.Name
+		}
+		applyField(m, field.Name, jsonName, field.Type, options)
+	}
+	return nil
+}
+
+// parseTag parses a `meta` tag such as `desc='Full name',minLength=1,required`.
+// Values containing commas are quoted with single quotes.
+func parseTag(tag string, fieldType reflect.Type) ([]FieldOption, error) {
+	items, err := splitTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var options []FieldOption
+	for _, item := range items {
+		key, value, hasValue := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if option, ok := tagFlags[key]; ok {
+			if hasValue {
+				return nil, fmt.Errorf("%s does not take a value", key)
+			}
+			options = append(options, option)
+			continue
+		}
+		parse, ok := tagOptions[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", key)
+		}
+		if !hasValue {
+			return nil, fmt.Errorf("%s requires a value", key)
+		}
+		option, err := parse(unquoteTagValue(strings.TrimSpace(value)), fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+// splitTag splits tag on commas outside single quotes, dropping empty items.
+func splitTag(tag string) ([]string, error) {
+	var items []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range tag {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == ',' && !quoted:
+			if item := strings.TrimSpace(current.String()); item != "" {
+				items = append(items, item)
+			}
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if item := strings.TrimSpace(current.String()); item != "" {
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func unquoteTagValue(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func floatOption[D FieldOption](fn func(float64) D) func(string, reflect.Type) (FieldOption, error) {
+	return func(value string, _ reflect.Type) (FieldOption, error) {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", value)
+		}
+		return fn(number), nil
+	}
+}
+
+func intOption[D FieldOption](fn func(int) D) func(string, reflect.Type) (FieldOption, error) {
+	return func(value string, _ reflect.Type) (FieldOption, error) {
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid length %q", value)
+		}
+		return fn(number), nil
+	}
+}
+
+func parsePatternOption(value string, _ reflect.Type) (FieldOption, error) {
+	if _, err := regexp.Compile(value); err != nil {
+		return nil, err
+	}
+	return Pattern(value), nil
+}
+
+// parseExampleOption converts the example to the field type, for basic kinds.
+func parseExampleOption(value string, fieldType reflect.Type) (FieldOption, error) {
+	t := fieldType
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var (
+		example any
+		err     error
+	)
+	switch t.Kind() {
+	case reflect.Bool:
+		example, err = strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, t.Bits())
+		example = reflect.ValueOf(n).Convert(t).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, t.Bits())
+		example = reflect.ValueOf(n).Convert(t).Interface()
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		n, err = strconv.ParseFloat(value, t.Bits())
+		example = reflect.ValueOf(n).Convert(t).Interface()
+	default:
+		example = value
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid example %q for %s", value, fieldType)
+	}
+	return exampleDecorator{Value: example}, nil
+}
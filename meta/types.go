@@ -18,6 +18,8 @@ type ExternalDocs struct {
 type ObjectMetadata struct {
 	Title        string
 	Description  string
+	Titles       Text // Localized titles, nil unless Title was given a Text
+	Descriptions Text // Localized descriptions, nil unless Description was given a Text
 	Deprecated   bool
 	ExternalDocs *ExternalDocs
 	Throws       []ThrowsMetadata
@@ -25,11 +27,15 @@ type ObjectMetadata struct {
 	Required     []string // Automatically populated from fields
 	Example      any
 	Type         reflect.Type
+	// Embedded lists the struct types embedded without a json name, whose fields are
+	// promoted. Metadata registered for them is inherited by this type.
+	Embedded []reflect.Type
 }
 
 // FieldMetadata holds documentation for a specific field within a struct.
 type FieldMetadata struct {
 	Description  string
+	Descriptions Text // Localized descriptions, nil unless Description was given a Text
 	Example      any
 	Type         reflect.Type
 	JSONName     string
@@ -59,6 +65,29 @@ type ThrowsMetadata struct {
 	Description string
 }
 
+// OperationMetadata documents an operation, such as an HTTP endpoint or a cqrs
+// command or query, registered by name or by its message type.
+type OperationMetadata struct {
+	Name         string
+	Summary      string
+	Description  string
+	Summaries    Text // Localized summaries, nil unless Summary was given a Text
+	Descriptions Text // Localized descriptions, nil unless Description was given a Text
+	Tags         []string
+	Input        reflect.Type // Request or message type, without pointer
+	Output       reflect.Type // Response or result type, without pointer
+	Throws       []ThrowsMetadata
+	Security     []SecurityRequirement
+	Deprecated   bool
+	ExternalDocs *ExternalDocs
+}
+
+// SecurityRequirement is an authentication scheme required by an operation.
+type SecurityRequirement struct {
+	Scheme string
+	Scopes []string
+}
+
 // ObjectOption defines the interface for decorators that apply to the whole struct.
 type ObjectOption interface {
 	applyToObject(structPointer any, metadata *ObjectMetadata)
@@ -68,3 +97,8 @@ type ObjectOption interface {
 type FieldOption interface {
 	applyToField(fieldMetadata *FieldMetadata)
 }
+
+// OperationOption defines the interface for decorators that apply to an operation.
+type OperationOption interface {
+	applyToOperation(operationMetadata *OperationMetadata)
+}
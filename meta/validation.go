@@ -0,0 +1,237 @@
+package meta
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/leandroluk/gox/validate"
+)
+
+// CodeMultipleOf is the issue code reported for values that are not a multiple of
+// the MultipleOf constraint, which validate has no number rule for.
+const CodeMultipleOf = "number.multipleOf"
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// ValidationSchema compiles the metadata registered for the struct type T into a
+// validate object schema, so documentation and runtime validation share a single
+// source. Each direct field is declared with the rule matching its type:
+//
+//	string         Text: Required, MinLength, MaxLength, Pattern, Enum and the email,
+//	               uuid, uri and url formats
+//	numbers        Number: Required, Min, Max and MultipleOf
+//	slices         Array: Required, MinItems and MaxItems
+//	bool           Boolean: Required
+//	time.Time      Date: Required
+//	time.Duration  Duration: Required
+//	maps           Record: Required
+//
+// Other fields are decoded without rules. Constraints registered for nested fields
+// (e.g. "Address.City") are not compiled. Fields promoted from structs embedded by
+// value are declared like direct fields, with the metadata of T or else of the
+// embedded type. Structs embedded by pointer cannot be decoded by validate, so they
+// make Validate return a build error instead of dropping their fields.
+func ValidationSchema[T any]() *validate.ObjectSchema[T] {
+	m := GetObjectMetadataAs[T]()
+	return validate.Object(func(target *T, s *validate.ObjectSchema[T]) {
+		tv := reflect.ValueOf(target).Elem()
+		for _, field := range reflect.VisibleFields(tv.Type()) {
+			if !field.IsExported() || strings.Split(field.Tag.Get("json"), ",")[0] == "-" {
+				continue
+			}
+			embedder, ok := promotedThroughValues(tv.Type(), field.Index)
+			if !ok {
+				continue
+			}
+			if promotes(field) {
+				if field.Type.Kind() == reflect.Pointer {
+					// validate reports a build error for the embedded pointer.
+					s.Field(tv.FieldByIndex(field.Index).Addr().Interface())
+				}
+				continue
+			}
+
+			var fm *FieldMetadata
+			if m != nil {
+				fm = m.Fields[field.Name]
+			}
+			if fm == nil && embedder != nil {
+				if em := GetObjectMetadataByType(embedder); em != nil {
+					fm = em.Fields[field.Name]
+				}
+			}
+			validationField(s.Field(tv.FieldByIndex(field.Index).Addr().Interface()), field.Type, fm)
+			if fm != nil && fm.MultipleOf != nil {
+				s.Custom(multipleOfRule[T](field.Index, jsonFieldName(field), *fm.MultipleOf))
+			}
+		}
+	})
+}
+
+// promotedThroughValues reports whether the field of t at index is reached only
+// through structs embedded by value without a json name, and returns the struct
+// declaring it when promoted.
+func promotedThroughValues(t reflect.Type, index []int) (reflect.Type, bool) {
+	var embedder reflect.Type
+	for _, i := range index[:len(index)-1] {
+		field := t.Field(i)
+		if !promotes(field) || field.Type.Kind() != reflect.Struct {
+			return nil, false
+		}
+		t = field.Type
+		embedder = t
+	}
+	return embedder, true
+}
+
+// promotes reports whether field is an embedded struct, or pointer to one, without
+// a json name, whose fields encoding/json promotes to the parent object.
+func promotes(field reflect.StructField) bool {
+	if !field.Anonymous || strings.Split(field.Tag.Get("json"), ",")[0] != "" {
+		return false
+	}
+	return field.Type.Kind() == reflect.Struct ||
+		field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct
+}
+
+// validationField declares the rules of a field from its metadata.
+func validationField[T any](fb *validate.FieldBuilder[T], t reflect.Type, fm *FieldMetadata) {
+	if fm == nil {
+		fb.Custom(nil)
+		return
+	}
+	base := t
+	if base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	switch {
+	case base == timeType:
+		b := fb.Date()
+		if fm.Required {
+			b.Required()
+		}
+		return
+	case base == durationType:
+		b := fb.Duration()
+		if fm.Required {
+			b.Required()
+		}
+		return
+	}
+
+	switch base.Kind() {
+	case reflect.String:
+		b := fb.Text()
+		if fm.Required {
+			b.Required()
+		}
+		if fm.MinLength != nil {
+			b.Min(*fm.MinLength)
+		}
+		if fm.MaxLength != nil {
+			b.Max(*fm.MaxLength)
+		}
+		if fm.Pattern != "" {
+			b.Pattern(fm.Pattern)
+		}
+		if len(fm.Enum) > 0 {
+			b.OneOf(fm.Enum...)
+		}
+		switch fm.Format {
+		case "email":
+			b.Email()
+		case "uuid":
+			b.UUID()
+		case "uri":
+			b.URI()
+		case "url":
+			b.URL()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		b := fb.Number()
+		if fm.Required {
+			b.Required()
+		}
+		if fm.Min != nil {
+			b.Min(*fm.Min)
+		}
+		if fm.Max != nil {
+			b.Max(*fm.Max)
+		}
+	case reflect.Slice:
+		b := fb.Array()
+		if fm.Required {
+			b.Required()
+		}
+		if fm.MinItems != nil {
+			b.Min(*fm.MinItems)
+		}
+		if fm.MaxItems != nil {
+			b.Max(*fm.MaxItems)
+		}
+	case reflect.Bool:
+		b := fb.Boolean()
+		if fm.Required {
+			b.Required()
+		}
+	case reflect.Map:
+		b := fb.Record()
+		if fm.Required {
+			b.Required()
+		}
+	default:
+		fb.Custom(nil)
+	}
+}
+
+// multipleOfRule reports the field at index when its numeric value is not a
+// multiple of divisor.
+func multipleOfRule[T any](index []int, name string, divisor float64) validate.RuleFn[T] {
+	return func(value T, reporter validate.Reporter) bool {
+		fv := reflect.ValueOf(value).FieldByIndex(index)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				return false
+			}
+			fv = fv.Elem()
+		}
+		var number float64
+		switch {
+		case fv.CanInt():
+			number = float64(fv.Int())
+		case fv.CanUint():
+			number = float64(fv.Uint())
+		case fv.CanFloat():
+			number = fv.Float()
+		default:
+			return false
+		}
+		quotient := number / divisor
+		if math.Abs(quotient-math.Round(quotient)) < 1e-9 {
+			return false
+		}
+
+		context, ok := reporter.(*validate.Context)
+		if ok {
+			context.PushField(name)
+			defer context.Pop()
+		}
+		return reporter.AddIssue(CodeMultipleOf, "not a multiple", map[string]any{
+			"multipleOf": divisor,
+			"actual":     number,
+		})
+	}
+}
+
+// jsonFieldName returns the name of field in its JSON encoding.
+func jsonFieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
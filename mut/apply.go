@@ -0,0 +1,295 @@
+package mut
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrMismatch matches every *MismatchError via errors.Is.
+var ErrMismatch = errors.New("mut: field types do not match")
+
+// MismatchError reports a dirty field whose value cannot be assigned to the matching
+// destination field.
+type MismatchError struct {
+	Field  string
+	Source reflect.Type
+	Target reflect.Type
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("mut: %s: cannot apply %v to %v", e.Field, e.Source, e.Target)
+}
+
+func (e *MismatchError) Is(target error) bool {
+	return target == ErrMismatch
+}
+
+// Change is the before and after value of a field updated by ApplyDiff. Explicit
+// nulls and nil pointers are nil.
+type Change struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// Apply copies the dirty Mut fields of src into the struct pointed to by dst. Fields
+// are matched by Go name, then by json name, and the value of a Mut[T] is assigned
+// to a T, *T or Mut[T] field; named types of the same kind are converted. Explicit
+// nulls reset T fields, set *T fields to nil and Mut fields to null. Nested structs
+// are applied recursively, skipping references back to a source struct already
+// being applied, and dirty fields without a match are ignored.
+//
+// Every field is checked before any is written: on mismatch dst is left unchanged
+// and the *MismatchError of each field is returned, joined.
+func Apply(dst, src any) error {
+	_, err := ApplyDiff(dst, src)
+	return err
+}
+
+// ApplyDiff is like Apply but also returns the changes made, keyed by the dotted
+// json path of the source field (as ToFlatMap), for audit logging.
+func ApplyDiff(dst, src any) ([]Change, error) {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mut: Apply destination must be a non-nil pointer to struct, got %T", dst)
+	}
+	p := &applyPlan{visiting: make(map[reference]bool)}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return nil, nil
+		}
+		p.visiting[referenceOf(sv)] = true
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mut: Apply source must be a struct, got %T", src)
+	}
+
+	p.plan(dv.Elem(), addressable(sv), nil)
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+	changes := make([]Change, 0, len(p.changes))
+	for i, write := range p.writes {
+		write()
+		if p.changes[i] != nil {
+			changes = append(changes, *p.changes[i])
+		}
+	}
+	return changes, nil
+}
+
+// applyPlan collects the writes of Apply, so nothing is written on mismatch. Writes
+// without a change allocate nil pointers to nested structs. visiting holds the source
+// pointers being planned, so cyclic sources terminate.
+type applyPlan struct {
+	writes   []func()
+	changes  []*Change
+	errs     []error
+	visiting map[reference]bool
+}
+
+func (p *applyPlan) plan(dst, src reflect.Value, path []string) {
+	targets := applyTargets(dst)
+	for _, field := range applySources(src) {
+		target, ok := targets[field.Name]
+		if !ok {
+			target, ok = targets[field.Key]
+		}
+		if !ok {
+			continue
+		}
+		fieldPath := appendPath(path, field.Key)
+
+		if field.Mutable == nil {
+			p.planNested(target, field.Value, fieldPath)
+			continue
+		}
+		if !field.Mutable.Dirty() {
+			continue
+		}
+		p.planField(target, field.Value, field.Mutable.IsNull(), strings.Join(fieldPath, "."))
+	}
+}
+
+// planNested applies the nested struct src onto target, a struct or pointer to one.
+func (p *applyPlan) planNested(target, src reflect.Value, path []string) {
+	if src.Kind() == reflect.Pointer {
+		if src.IsNil() || p.visiting[referenceOf(src)] {
+			return
+		}
+		p.visiting[referenceOf(src)] = true
+		defer delete(p.visiting, referenceOf(src))
+		src = src.Elem()
+	}
+	switch {
+	case target.Kind() == reflect.Struct:
+		p.plan(target, src, path)
+	case target.Kind() == reflect.Pointer && target.Type().Elem().Kind() == reflect.Struct:
+		if !target.IsNil() {
+			p.plan(target.Elem(), src, path)
+			return
+		}
+		// Plan against a new value, assigned only if a nested field is written.
+		value := reflect.New(target.Type().Elem())
+		index := len(p.writes)
+		p.writes = append(p.writes, nil)
+		p.changes = append(p.changes, nil)
+		p.plan(value.Elem(), src, path)
+		if len(p.writes) > index+1 {
+			p.writes[index] = func() { target.Set(value) }
+		} else {
+			p.writes, p.changes = p.writes[:index], p.changes[:index]
+		}
+	}
+}
+
+// planField plans the assignment of the value of a dirty Mut (a *Mut[T]) to target.
+func (p *applyPlan) planField(target, src reflect.Value, null bool, path string) {
+	value := src.Addr().MethodByName("Get").Call(nil)[0]
+	var after any
+	if !null {
+		after = value.Interface()
+	}
+	change := &Change{Field: path, Before: currentValue(target), After: after}
+
+	var write func()
+	if m, ok := target.Addr().Interface().(mutable); ok {
+		set := target.Addr().MethodByName("Set")
+		converted, ok := convertValue(value, set.Type().In(0))
+		switch {
+		case null:
+			write = m.(interface{ SetNull() }).SetNull
+		case ok:
+			write = func() { set.Call([]reflect.Value{converted}) }
+		}
+	} else if target.Kind() == reflect.Pointer {
+		if converted, ok := convertValue(value, target.Type().Elem()); null {
+			write = func() { target.SetZero() }
+		} else if ok {
+			write = func() {
+				pointer := reflect.New(target.Type().Elem())
+				pointer.Elem().Set(converted)
+				target.Set(pointer)
+			}
+		}
+	}
+	if write == nil {
+		if converted, ok := convertValue(value, target.Type()); null {
+			write = func() { target.SetZero() }
+		} else if ok {
+			write = func() { target.Set(converted) }
+		}
+	}
+	if write == nil {
+		p.errs = append(p.errs, &MismatchError{Field: path, Source: value.Type(), Target: target.Type()})
+		return
+	}
+	p.writes = append(p.writes, write)
+	p.changes = append(p.changes, change)
+}
+
+// applySource is a field of the source struct: a Mut (with Mutable set) or a
+// candidate nested struct.
+type applySource struct {
+	Name    string
+	Key     string
+	Value   reflect.Value
+	Mutable mutable
+}
+
+func applySources(v reflect.Value) []applySource {
+	var out []applySource
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if promotesFields(field) && strings.Split(field.Tag.Get("json"), ",")[0] == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			out = append(out, applySources(fv)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		m, isMutable := fv.Addr().Interface().(mutable)
+		switch {
+		case isMutable:
+			out = append(out, applySource{Name: field.Name, Key: fieldKey(field), Value: fv, Mutable: m})
+		case isStructType(field.Type):
+			out = append(out, applySource{Name: field.Name, Key: fieldKey(field), Value: fv})
+		}
+	}
+	return out
+}
+
+// applyTargets maps the Go and json names of the fields of the struct v, including
+// those promoted from non-nil embedded structs, to their values.
+func applyTargets(v reflect.Value) map[string]reflect.Value {
+	out := make(map[string]reflect.Value)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if promotesFields(field) && strings.Split(field.Tag.Get("json"), ",")[0] == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			for key, promoted := range applyTargets(fv) {
+				if _, exists := out[key]; !exists {
+					out[key] = promoted
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		out[field.Name] = fv
+		if key := fieldKey(field); key != field.Name {
+			if _, exists := out[key]; !exists {
+				out[key] = fv
+			}
+		}
+	}
+	return out
+}
+
+// convertValue returns value as type t, when assignable or of the same kind.
+func convertValue(value reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	switch {
+	case value.Type().AssignableTo(t):
+		return value, true
+	case value.Kind() == t.Kind() && value.Type().ConvertibleTo(t):
+		return value.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
+
+// currentValue returns the value of a destination field for Change.Before.
+func currentValue(v reflect.Value) any {
+	if m, ok := v.Addr().Interface().(mutable); ok {
+		if m.IsNull() {
+			return nil
+		}
+		return m.GetAny()
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	}
+	return v.Interface()
+}
@@ -1,8 +1,11 @@
 package mut
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -13,9 +16,11 @@ type Mutable[T any] interface {
 	Dirty() bool
 }
 
-// Mut is the structure that tracks the value state.
+// Mut is the structure that tracks the value state: missing (not dirty), explicitly
+// null (dirty and null) or set to a value (dirty).
 type Mut[T any] struct {
 	dirty bool
+	null  bool
 	value T
 }
 
@@ -26,17 +31,39 @@ func (m *Mut[T]) Get() T { return m.value }
 func (m *Mut[T]) Dirty() bool { return m.dirty }
 
 // Set updates the value and marks it as dirty.
-func (m *Mut[T]) Set(v T) { m.dirty = true; m.value = v }
+func (m *Mut[T]) Set(v T) { m.dirty = true; m.null = false; m.value = v }
+
+// IsNull returns true if the value was explicitly set to null.
+func (m *Mut[T]) IsNull() bool { return m.null }
+
+// SetNull clears the value to its zero value and marks it as dirty and null.
+func (m *Mut[T]) SetNull() {
+	var zero T
+	m.dirty = true
+	m.null = true
+	m.value = zero
+}
 
 // GetAny is a bridge method for ToMap using reflection.
 func (m *Mut[T]) GetAny() any { return m.value }
 
-// MarshalJSON implements the json.Marshaler interface.
-func (m *Mut[T]) MarshalJSON() ([]byte, error) { return json.Marshal(m.value) }
+// MarshalJSON implements the json.Marshaler interface, emitting null for explicit nulls.
+func (m *Mut[T]) MarshalJSON() ([]byte, error) {
+	if m.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(m.value)
+}
 
 // UnmarshalJSON implements the json.Unmarshaler interface and marks the value as dirty.
+// A JSON null sets the zero value and marks the value as null.
 func (m *Mut[T]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		m.SetNull()
+		return nil
+	}
 	m.dirty = true
+	m.null = false
 	return json.Unmarshal(data, &m.value)
 }
 
@@ -48,49 +75,203 @@ func New[T any](val ...T) Mut[T] {
 	return Mut[T]{}
 }
 
+// mutable is implemented by *Mut[T] and identifies Mut fields via reflection.
+type mutable interface {
+	GetAny() any
+	Dirty() bool
+	IsNull() bool
+}
+
+var mutableType = reflect.TypeFor[mutable]()
+
 // ToMap converts structs with Mut fields into map[string]any, including only dirty fields.
+// Explicit nulls are included with a nil value.
+// Nested structs, pointers to structs and collections of structs are tracked
+// recursively as nested maps keyed by json name, slice index or map key; embedded
+// structs are flattened like encoding/json does, and clean subtrees are omitted.
 func ToMap(obj any) map[string]any {
-	// internalMutable is used to identify Mut fields via reflection.
-	type internalMutable interface {
-		GetAny() any
-		Dirty() bool
-	}
+	out := make(map[string]any)
+	walkDirty(obj, false, func(path []string, value any, _ bool) {
+		current := out
+		for _, key := range path[:len(path)-1] {
+			next, ok := current[key].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				current[key] = next
+			}
+			current = next
+		}
+		current[path[len(path)-1]] = value
+	})
+	return out
+}
 
+// ToFlatMap is like ToMap but returns the dirty fields keyed by dotted path, such as
+// "address.city" or "items.0.name".
+func ToFlatMap(obj any) map[string]any {
 	out := make(map[string]any)
-	v := reflect.ValueOf(obj)
+	walkDirty(obj, false, func(path []string, value any, _ bool) {
+		out[strings.Join(path, ".")] = value
+	})
+	return out
+}
 
+// walkDirty calls visit with the path and value of every dirty Mut reachable from obj,
+// with a nil value for explicit nulls. Fields tagged json:"-" are skipped when
+// skipIgnored is set. References back to a pointer or slice being walked are skipped,
+// so cyclic structures terminate.
+func walkDirty(obj any, skipIgnored bool, visit func(path []string, value any, null bool)) {
+	v := reflect.ValueOf(obj)
 	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
 		v = v.Elem()
 	}
 	if v.Kind() != reflect.Struct {
-		return out
+		return
 	}
+	w := &dirtyWalker{visit: visit, skipIgnored: skipIgnored, visiting: make(map[reference]bool)}
+	if v.CanAddr() {
+		w.visiting[referenceOf(v.Addr())] = true
+	}
+	// Structs passed by value are copied so pointer receiver methods can be called.
+	w.value(addressable(v), nil)
+}
 
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-
-		// Attempt to get the field address to access pointer receiver methods.
-		var ptr reflect.Value
-		if field.CanAddr() {
-			ptr = field.Addr()
-		} else {
-			// Fallback for structs passed by value.
-			c := reflect.New(field.Type())
-			c.Elem().Set(field)
-			ptr = c
-		}
+// dirtyWalker holds the state of walkDirty.
+type dirtyWalker struct {
+	visit       func(path []string, value any, null bool)
+	skipIgnored bool
+	visiting    map[reference]bool
+}
+
+// reference identifies the target of a pointer or the backing array of a slice.
+type reference struct {
+	pointer uintptr
+	typ     reflect.Type
+}
+
+func referenceOf(v reflect.Value) reference {
+	return reference{pointer: v.Pointer(), typ: v.Type()}
+}
 
-		if m, ok := ptr.Interface().(internalMutable); ok {
-			if m.Dirty() {
-				tag := t.Field(i).Tag.Get("json")
-				key := strings.Split(tag, ",")[0]
-				if key == "" || key == "-" {
-					key = t.Field(i).Name
+// enter marks the reference of v as being walked, reporting false if it already is.
+// The returned function unmarks it.
+func (w *dirtyWalker) enter(v reflect.Value) (func(), bool) {
+	ref := referenceOf(v)
+	if w.visiting[ref] {
+		return nil, false
+	}
+	w.visiting[ref] = true
+	return func() { delete(w.visiting, ref) }, true
+}
+
+func (w *dirtyWalker) value(v reflect.Value, path []string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		leave, ok := w.enter(v)
+		if !ok {
+			return
+		}
+		defer leave()
+		w.value(v.Elem(), path)
+	case reflect.Struct:
+		if reflect.PointerTo(v.Type()).Implements(mutableType) {
+			m := v.Addr().Interface().(mutable)
+			if m.Dirty() && len(path) > 0 {
+				if m.IsNull() {
+					w.visit(path, nil, true)
+				} else {
+					w.visit(path, m.GetAny(), false)
 				}
-				out[key] = m.GetAny()
 			}
+			return
+		}
+		w.fields(v, path)
+	case reflect.Slice, reflect.Array:
+		if !isStructType(v.Type().Elem()) {
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			leave, ok := w.enter(v)
+			if !ok {
+				return
+			}
+			defer leave()
+		}
+		for i := 0; i < v.Len(); i++ {
+			w.value(v.Index(i), appendPath(path, strconv.Itoa(i)))
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || !isStructType(v.Type().Elem()) {
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			w.value(addressable(v.MapIndex(key)), appendPath(path, key.String()))
 		}
 	}
-	return out
+}
+
+func (w *dirtyWalker) fields(v reflect.Value, path []string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if promotesFields(field) && strings.Split(field.Tag.Get("json"), ",")[0] == "" {
+			w.value(v.Field(i), path)
+			continue
+		}
+		if !field.IsExported() || w.skipIgnored && field.Tag.Get("json") == "-" {
+			continue
+		}
+		w.value(v.Field(i), appendPath(path, fieldKey(field)))
+	}
+}
+
+// fieldKey returns the json name of field, or its Go name when untagged or tagged
+// "-". As in encoding/json, a "-," tag names the field "-".
+func fieldKey(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	key := strings.Split(tag, ",")[0]
+	if key == "" || tag == "-" {
+		key = field.Name
+	}
+	return key
+}
+
+// promotesFields reports whether field embeds a struct, other than a Mut, whose
+// fields are promoted into its parent. As in encoding/json, an unexported embedded
+// struct still promotes its exported fields, while an unexported embedded pointer
+// is skipped.
+func promotesFields(field reflect.StructField) bool {
+	if !field.Anonymous || !isStructType(field.Type) || reflect.PointerTo(field.Type).Implements(mutableType) {
+		return false
+	}
+	return field.IsExported() || field.Type.Kind() == reflect.Struct
+}
+
+// isStructType reports whether t is a struct or a pointer to one.
+func isStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+func appendPath(path []string, key string) []string {
+	return append(path[:len(path):len(path)], key)
 }
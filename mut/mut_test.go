@@ -1,7 +1,10 @@
 package mut_test
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"reflect"
 	"testing"
 
 	mut "github.com/leandroluk/gox/mut"
@@ -107,3 +110,420 @@ func TestToMap_Coverage(t *testing.T) {
 		}
 	})
 }
+
+type Address struct {
+	City mut.Mut[string] `json:"city"`
+	Zip  mut.Mut[string] `json:"zip"`
+}
+
+type Audit struct {
+	UpdatedBy mut.Mut[string] `json:"updatedBy"`
+}
+
+// revision is unexported, so its fields are only reachable through embedding.
+type revision struct {
+	Revision mut.Mut[int] `json:"revision"`
+}
+
+type Revised struct {
+	revision
+	Title mut.Mut[string] `json:"title"`
+}
+
+type Item struct {
+	Name mut.Mut[string] `json:"name"`
+}
+
+type Profile struct {
+	Audit
+	Name     mut.Mut[string]  `json:"name"`
+	Address  Address          `json:"address"`
+	Billing  *Address         `json:"billing"`
+	Shipping *Address         `json:"shipping"`
+	Items    []Item           `json:"items"`
+	Labels   map[string]*Item `json:"labels"`
+	Home     mut.Mut[Address] `json:"home"`
+	Tags     []string         `json:"tags"`
+}
+
+func TestToMap_Nested(t *testing.T) {
+	p := Profile{Billing: &Address{}, Items: make([]Item, 2), Labels: map[string]*Item{"a": {}, "b": {}}}
+	p.UpdatedBy.Set("admin")
+	p.Address.City.Set("Recife")
+	p.Billing.Zip.Set("50000-000")
+	p.Items[1].Name.Set("pen")
+	p.Labels["b"].Name.Set("blue")
+	p.Tags = []string{"ignored"}
+
+	res := mut.ToMap(p)
+	b, _ := json.Marshal(res)
+	expected := `{"address":{"city":"Recife"},"billing":{"zip":"50000-000"},"items":{"1":{"name":"pen"}},"labels":{"b":{"name":"blue"}},"updatedBy":"admin"}`
+	if string(b) != expected {
+		t.Errorf("unexpected nested map:\n got %s\nwant %s", b, expected)
+	}
+
+	flat := mut.ToFlatMap(&p)
+	b, _ = json.Marshal(flat)
+	expected = `{"address.city":"Recife","billing.zip":"50000-000","items.1.name":"pen","labels.b.name":"blue","updatedBy":"admin"}`
+	if string(b) != expected {
+		t.Errorf("unexpected flat map:\n got %s\nwant %s", b, expected)
+	}
+
+	p.Home.Set(Address{})
+	if _, ok := mut.ToFlatMap(&p)["home"].(Address); !ok {
+		t.Error("a dirty Mut of a struct should be reported as a whole value")
+	}
+	if len(mut.ToFlatMap(&Profile{})) != 0 || len(mut.ToMap((*Profile)(nil))) != 0 {
+		t.Error("clean or nil structs should produce empty maps")
+	}
+
+	r := &Revised{}
+	r.Revision.Set(2)
+	if b, _ := json.Marshal(mut.ToMap(r)); string(b) != `{"revision":2}` {
+		t.Errorf("expected fields promoted from an unexported embedded struct, got %s", b)
+	}
+}
+
+type Node struct {
+	Name     mut.Mut[string] `json:"name"`
+	Next     *Node           `json:"next"`
+	Children []Node          `json:"children"`
+}
+
+func TestToMap_Cycles(t *testing.T) {
+	n := &Node{}
+	n.Name.Set("root")
+	n.Next = n
+	if b, _ := json.Marshal(mut.ToMap(n)); string(b) != `{"name":"root"}` {
+		t.Errorf("unexpected map of a self reference: %s", b)
+	}
+	if b, _ := json.Marshal(mut.ToFlatMap(*n)); string(b) != `{"name":"root","next.name":"root"}` {
+		t.Errorf("unexpected flat map of a copied self reference: %s", b)
+	}
+	if b, _ := json.Marshal(mut.ToJSONPatch(n)); string(b) != `[{"op":"add","path":"/name","value":"root"}]` {
+		t.Errorf("unexpected patch of a self reference: %s", b)
+	}
+
+	children := make([]Node, 1)
+	children[0].Name.Set("child")
+	children[0].Children = children
+	if b, _ := json.Marshal(mut.ToFlatMap(&Node{Children: children})); string(b) != `{"children.0.name":"child"}` {
+		t.Errorf("unexpected flat map of a cyclic slice: %s", b)
+	}
+
+	shared := &Node{}
+	shared.Name.Set("shared")
+	if b, _ := json.Marshal(mut.ToFlatMap(&Node{Next: shared, Children: []Node{{Next: shared}}})); string(b) != `{"children.0.next.name":"shared","next.name":"shared"}` {
+		t.Errorf("expected shared pointers under every path, got %s", b)
+	}
+}
+
+func TestMut_Null(t *testing.T) {
+	var body struct {
+		Name  mut.Mut[string] `json:"name"`
+		Age   mut.Mut[int]    `json:"age"`
+		Email mut.Mut[string] `json:"email"`
+	}
+	if err := json.Unmarshal([]byte(`{"name": null, "age": 0}`), &body); err != nil {
+		t.Fatal(err)
+	}
+	if !body.Name.Dirty() || !body.Name.IsNull() || body.Name.Get() != "" {
+		t.Error("null should be dirty and null")
+	}
+	if !body.Age.Dirty() || body.Age.IsNull() {
+		t.Error("zero should be dirty and not null")
+	}
+	if body.Email.Dirty() || body.Email.IsNull() {
+		t.Error("missing should be neither dirty nor null")
+	}
+
+	res := mut.ToMap(&body)
+	if value, ok := res["name"]; !ok || value != nil {
+		t.Errorf("expected explicit nil for name, got %v", res)
+	}
+	if res["age"] != 0 || len(res) != 2 {
+		t.Errorf("expected age 0 and no email, got %v", res)
+	}
+
+	b, _ := json.Marshal(&body)
+	if string(b) != `{"name":null,"age":0,"email":""}` {
+		t.Errorf("unexpected marshal output: %s", b)
+	}
+
+	body.Name.Set("Ann")
+	if body.Name.IsNull() {
+		t.Error("Set should clear null")
+	}
+	body.Age.SetNull()
+	if !body.Age.IsNull() || !body.Age.Dirty() || body.Age.Get() != 0 {
+		t.Error("SetNull should mark dirty and null")
+	}
+}
+
+type UserRow struct {
+	Audit
+	Name     mut.Mut[string] `json:"name" db:"full_name"`
+	Age      mut.Mut[int]    `json:"age"`
+	Nickname mut.Mut[string] `json:"nickname"`
+	Secret   mut.Mut[string] `db:"-"`
+	Address  Address         `json:"address"`
+}
+
+func TestUpdateSQL(t *testing.T) {
+	u := &UserRow{}
+	u.UpdatedBy.Set("admin")
+	u.Name.Set("Ann")
+	u.Nickname.SetNull()
+	u.Secret.Set("ignored")
+	u.Address.City.Set("ignored")
+	where := map[string]any{"tenant_id": 7, "id": 42}
+
+	for name, c := range map[string]struct {
+		placeholder mut.Placeholder
+		query       string
+		args        []any
+	}{
+		"dollar": {mut.Dollar, "UPDATE users SET updatedBy = $1, full_name = $2, nickname = $3 WHERE id = $4 AND tenant_id = $5",
+			[]any{"admin", "Ann", nil, 42, 7}},
+		"question": {mut.Question, "UPDATE users SET updatedBy = ?, full_name = ?, nickname = ? WHERE id = ? AND tenant_id = ?",
+			[]any{"admin", "Ann", nil, 42, 7}},
+		"named": {mut.AtName, "UPDATE users SET updatedBy = @updatedBy, full_name = @full_name, nickname = @nickname WHERE id = @where_id AND tenant_id = @where_tenant_id",
+			[]any{sql.Named("updatedBy", "admin"), sql.Named("full_name", "Ann"), sql.Named("nickname", nil), sql.Named("where_id", 42), sql.Named("where_tenant_id", 7)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			query, args, err := mut.UpdateSQL("users", u, where, mut.WithPlaceholder(c.placeholder))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != c.query {
+				t.Errorf("unexpected query:\n got %s\nwant %s", query, c.query)
+			}
+			if !reflect.DeepEqual(args, c.args) {
+				t.Errorf("unexpected args: %#v", args)
+			}
+		})
+	}
+
+	if _, _, err := mut.UpdateSQL("users", &UserRow{}, where); !errors.Is(err, mut.ErrNoChanges) {
+		t.Errorf("expected ErrNoChanges, got %v", err)
+	}
+	if _, _, err := mut.UpdateSQL("users", u, nil); !errors.Is(err, mut.ErrNoWhere) {
+		t.Errorf("expected ErrNoWhere, got %v", err)
+	}
+
+	r := &Revised{}
+	r.Revision.Set(3)
+	if query, args, err := mut.UpdateSQL("posts", r, map[string]any{"id": 1}); err != nil ||
+		query != "UPDATE posts SET revision = $1 WHERE id = $2" || !reflect.DeepEqual(args, []any{3, 1}) {
+		t.Errorf("expected columns promoted from an unexported embedded struct, got %s %v (%v)", query, args, err)
+	}
+}
+
+type Document struct {
+	Audit
+	Title     mut.Mut[string]   `json:"title"`
+	Count     mut.Mut[int]      `json:"count"`
+	Address   Address           `json:"address"`
+	Billing   *Address          `json:"billing"`
+	Meta      map[string]string `json:"meta"`
+	Tags      []string          `json:"tags"`
+	Plain     string            `json:"plain"`
+	Untouched mut.Mut[string]   `json:"untouched"`
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	d := &Document{Meta: map[string]string{"keep": "1", "drop": "2"}, Tags: []string{"a", "b"}, Plain: "old"}
+	patch := `{
+		"updatedBy": "admin",
+		"title": "Hello",
+		"count": null,
+		"address": {"city": "Recife", "zip": null},
+		"billing": {"zip": "123"},
+		"meta": {"drop": null, "add": "3"},
+		"tags": ["c"],
+		"plain": null,
+		"unknown": true
+	}`
+	if err := mut.ApplyMergePatch(d, []byte(patch)); err != nil {
+		t.Fatal(err)
+	}
+	if d.UpdatedBy.Get() != "admin" || d.Title.Get() != "Hello" || !d.Count.IsNull() || d.Untouched.Dirty() {
+		t.Errorf("unexpected Mut fields: %+v", d)
+	}
+	if d.Address.City.Get() != "Recife" || !d.Address.Zip.IsNull() || d.Billing == nil || d.Billing.Zip.Get() != "123" {
+		t.Errorf("unexpected nested fields: %+v %+v", d.Address, d.Billing)
+	}
+	if !reflect.DeepEqual(d.Meta, map[string]string{"keep": "1", "add": "3"}) || !reflect.DeepEqual(d.Tags, []string{"c"}) || d.Plain != "" {
+		t.Errorf("unexpected plain fields: %v %v %q", d.Meta, d.Tags, d.Plain)
+	}
+
+	for _, invalid := range []string{`[1]`, `{"count": "x"}`, `{"address": 1}`} {
+		if err := mut.ApplyMergePatch(&Document{}, []byte(invalid)); !errors.Is(err, mut.ErrInvalidPatch) {
+			t.Errorf("%s: expected ErrInvalidPatch, got %v", invalid, err)
+		}
+	}
+	if err := mut.ApplyMergePatch(Document{}, []byte(`{}`)); !errors.Is(err, mut.ErrInvalidPatch) {
+		t.Errorf("expected ErrInvalidPatch for non-pointer target, got %v", err)
+	}
+
+	var account struct {
+		Name    string `json:"name"`
+		Upper   string `json:"NAME"`
+		Dash    string `json:"-,"`
+		IsAdmin bool   `json:"-"`
+	}
+	if err := mut.ApplyMergePatch(&account, []byte(`{"isadmin": true, "IsAdmin": true, "-": "x", "NAME": "upper"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if account.IsAdmin || account.Dash != "x" || account.Upper != "upper" || account.Name != "" {
+		t.Errorf("expected json:\"-\" skipped and exact names matched, got %+v", account)
+	}
+	r := &Revised{}
+	if err := mut.ApplyMergePatch(r, []byte(`{"revision": 4, "title": "t"}`)); err != nil || r.Revision.Get() != 4 || r.Title.Get() != "t" {
+		t.Errorf("expected fields promoted from an unexported embedded struct to be set, got %+v (%v)", r, err)
+	}
+	for range 10 {
+		account.Name, account.Upper = "", ""
+		if err := mut.ApplyMergePatch(&account, []byte(`{"Name": "first"}`)); err != nil || account.Name != "first" || account.Upper != "" {
+			t.Fatalf("expected the first case-insensitive match, got %+v (%v)", account, err)
+		}
+	}
+}
+
+func TestToJSONPatch(t *testing.T) {
+	d := &Document{}
+	d.Title.Set("Hello")
+	d.Count.SetNull()
+	d.Address.City.Set("Recife")
+	p := &Profile{Labels: map[string]*Item{"a/b": {}}}
+	p.Labels["a/b"].Name.Set("x")
+
+	b, _ := json.Marshal(append(mut.ToJSONPatch(d), mut.ToJSONPatch(p)...))
+	expected := `[{"op":"add","path":"/title","value":"Hello"},{"op":"remove","path":"/count"},` +
+		`{"op":"add","path":"/address/city","value":"Recife"},{"op":"add","path":"/labels/a~1b/name","value":"x"}]`
+	if string(b) != expected {
+		t.Errorf("unexpected patch:\n got %s\nwant %s", b, expected)
+	}
+	if b, _ := json.Marshal(mut.ToJSONPatch(&Document{})); string(b) != "[]" {
+		t.Errorf("expected empty patch, got %s", b)
+	}
+
+	var secret struct {
+		Name  mut.Mut[string] `json:"name"`
+		Token mut.Mut[string] `json:"-"`
+	}
+	secret.Name.Set("n")
+	secret.Token.Set("hidden")
+	if b, _ := json.Marshal(mut.ToJSONPatch(&secret)); string(b) != `[{"op":"add","path":"/name","value":"n"}]` {
+		t.Errorf("expected json:\"-\" fields skipped, got %s", b)
+	}
+}
+
+type Status string
+
+type UserPatch struct {
+	Audit
+	Name     mut.Mut[string] `json:"name"`
+	Nickname mut.Mut[string] `json:"nick"`
+	Age      mut.Mut[int]    `json:"age"`
+	Status   mut.Mut[string] `json:"status"`
+	Email    mut.Mut[string] `json:"email"`
+	Address  Address         `json:"address"`
+	Billing  Address         `json:"billing"`
+	Missing  mut.Mut[string] `json:"missing"`
+}
+
+type UserEntity struct {
+	UpdatedBy mut.Mut[string]
+	Name      string
+	NickName  *string `json:"nick"`
+	Age       int
+	Status    Status
+	Email     string
+	Address   struct{ City, Zip string }
+	Billing   *struct{ Zip *string }
+}
+
+func TestApply(t *testing.T) {
+	nick := "neo"
+	e := &UserEntity{Name: "Old", NickName: &nick, Age: 40, Email: "kept@example.com"}
+	p := &UserPatch{}
+	p.UpdatedBy.Set("admin")
+	p.Name.Set("Ann")
+	p.Nickname.SetNull()
+	p.Age.Set(30)
+	p.Status.Set("active")
+	p.Address.City.Set("Recife")
+	p.Billing.Zip.Set("123")
+	p.Missing.Set("ignored")
+
+	changes, err := mut.ApplyDiff(e, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.UpdatedBy.Get() != "admin" || e.Name != "Ann" || e.NickName != nil || e.Age != 30 || e.Status != "active" || e.Email != "kept@example.com" {
+		t.Errorf("unexpected entity: %+v", e)
+	}
+	if e.Address.City != "Recife" || e.Billing == nil || e.Billing.Zip == nil || *e.Billing.Zip != "123" {
+		t.Errorf("unexpected nested fields: %+v %+v", e.Address, e.Billing)
+	}
+
+	got := map[string][2]any{}
+	for _, change := range changes {
+		got[change.Field] = [2]any{change.Before, change.After}
+	}
+	expected := map[string][2]any{
+		"updatedBy":    {"", "admin"},
+		"name":         {"Old", "Ann"},
+		"nick":         {"neo", nil},
+		"age":          {40, 30},
+		"status":       {Status(""), "active"},
+		"address.city": {"", "Recife"},
+		"billing.zip":  {nil, "123"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected changes:\n got %v\nwant %v", got, expected)
+	}
+
+	var target struct {
+		Name string
+		Age  string
+		Zip  bool `json:"zip"`
+	}
+	target.Name = "unchanged"
+	bad := &UserPatch{}
+	bad.Name.Set("x")
+	bad.Age.Set(1)
+	bad.Address.Zip.Set("1")
+	err = mut.Apply(&target, bad)
+	var mismatch *mut.MismatchError
+	if !errors.Is(err, mut.ErrMismatch) || !errors.As(err, &mismatch) || mismatch.Field != "age" {
+		t.Errorf("expected age mismatch, got %v", err)
+	}
+	if target.Name != "unchanged" {
+		t.Error("nothing should be written on mismatch")
+	}
+	if err := mut.Apply(target, bad); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+
+	revised := &Revised{}
+	revised.Revision.Set(5)
+	var copied Revised
+	var flat struct{ Revision int }
+	if err := mut.Apply(&copied, revised); err != nil || copied.Revision.Get() != 5 {
+		t.Errorf("expected an unexported embedded target to be written, got %+v (%v)", copied, err)
+	}
+	if err := mut.Apply(&flat, revised); err != nil || flat.Revision != 5 {
+		t.Errorf("expected an unexported embedded source to be read, got %+v (%v)", flat, err)
+	}
+
+	cyclic := &Node{}
+	cyclic.Name.Set("root")
+	cyclic.Next = cyclic
+	var node Node
+	changes, err = mut.ApplyDiff(&node, cyclic)
+	if err != nil || len(changes) != 1 || node.Name.Get() != "root" || node.Next != nil {
+		t.Errorf("expected a self reference applied once, got %v %v %+v", changes, err, node)
+	}
+}
@@ -0,0 +1,235 @@
+package mut
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidPatch is returned by ApplyMergePatch for malformed patches or values that
+// do not fit the target fields.
+var ErrInvalidPatch = errors.New("mut: invalid patch")
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7386, application/merge-patch+json)
+// to the struct pointed to by target. Members are matched by json name, like
+// encoding/json does, and fields tagged "-" are never set. Mut fields are set, or
+// set to null for null members, so they become dirty; nested structs and
+// string-keyed maps are merged recursively, null removing map entries; other fields
+// are replaced, null resetting them. Members without a matching field are ignored.
+func ApplyMergePatch(target any, patch []byte) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: target must be a non-nil pointer to struct, got %T", ErrInvalidPatch, target)
+	}
+	return mergeStruct(v.Elem(), patch, "")
+}
+
+func mergeStruct(v reflect.Value, data []byte, path string) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil || members == nil {
+		return fmt.Errorf("%w: %s must be an object", ErrInvalidPatch, pointerOrRoot(path))
+	}
+	fields := patchFields(v)
+	for key, raw := range members {
+		fv, ok := lookupPatchField(fields, key)
+		if !ok {
+			continue
+		}
+		if err := mergeValue(fv, raw, path+"/"+escapePointer(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeValue(v reflect.Value, raw json.RawMessage, path string) error {
+	if m, ok := v.Addr().Interface().(mutable); ok {
+		if err := m.(json.Unmarshaler).UnmarshalJSON(raw); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidPatch, path, err)
+		}
+		return nil
+	}
+	if isNullJSON(raw) {
+		v.SetZero()
+		return nil
+	}
+
+	t := v.Type()
+	isObject := bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{"))
+	switch {
+	case isObject && t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(unmarshalerType):
+		return mergeStruct(v, raw, path)
+	case isObject && t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && !t.Implements(unmarshalerType):
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+		return mergeStruct(v.Elem(), raw, path)
+	case isObject && t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		return mergeMap(v, raw, path)
+	}
+
+	value := reflect.New(t)
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidPatch, path, err)
+	}
+	v.Set(value.Elem())
+	return nil
+}
+
+func mergeMap(v reflect.Value, raw json.RawMessage, path string) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidPatch, path, err)
+	}
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	for key, member := range members {
+		mapKey := reflect.New(t.Key()).Elem()
+		mapKey.SetString(key)
+		if isNullJSON(member) {
+			v.SetMapIndex(mapKey, reflect.Value{})
+			continue
+		}
+		value := reflect.New(t.Elem()).Elem()
+		if existing := v.MapIndex(mapKey); existing.IsValid() {
+			value.Set(existing)
+		}
+		if err := mergeValue(value, member, path+"/"+escapePointer(key)); err != nil {
+			return err
+		}
+		v.SetMapIndex(mapKey, value)
+	}
+	return nil
+}
+
+// patchField is a field of a struct target of ApplyMergePatch.
+type patchField struct {
+	Key   string
+	Value reflect.Value
+	depth int
+}
+
+// patchFields returns the json names and values of the exported fields of the struct
+// v in declaration order, including those promoted from embedded structs. Like
+// encoding/json, fields tagged "-" are skipped and a promoted field is hidden by a
+// shallower one of the same name. Nil embedded pointers are allocated.
+func patchFields(v reflect.Value) []patchField {
+	all := collectPatchFields(nil, v, 0)
+	depths := make(map[string]int)
+	for _, field := range all {
+		if depth, ok := depths[field.Key]; !ok || field.depth < depth {
+			depths[field.Key] = field.depth
+		}
+	}
+	fields := make([]patchField, 0, len(depths))
+	for _, field := range all {
+		if depth, ok := depths[field.Key]; ok && depth == field.depth {
+			fields = append(fields, field)
+			delete(depths, field.Key)
+		}
+	}
+	return fields
+}
+
+func collectPatchFields(fields []patchField, v reflect.Value, depth int) []patchField {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if promotesFields(field) && strings.Split(field.Tag.Get("json"), ",")[0] == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv.Set(reflect.New(field.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			fields = collectPatchFields(fields, fv, depth+1)
+			continue
+		}
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		fields = append(fields, patchField{Key: fieldKey(field), Value: fv, depth: depth})
+	}
+	return fields
+}
+
+// lookupPatchField returns the field named key, preferring an exact match, then the
+// first case-insensitive match in declaration order, as encoding/json does.
+func lookupPatchField(fields []patchField, key string) (reflect.Value, bool) {
+	for _, field := range fields {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.Key, key) {
+			return field.Value, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// PatchOperation is an operation of a JSON Patch (RFC 6902) document.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// MarshalJSON omits the value of "remove" operations.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	type operation PatchOperation
+	return json.Marshal(operation(o))
+}
+
+// ToJSONPatch returns the JSON Patch (RFC 6902, application/json-patch+json)
+// operations for the dirty fields of obj: "add" with the value of set fields, which
+// also replaces existing members, and "remove" for explicit nulls, which requires the
+// member to exist. Paths are JSON Pointers built like ToFlatMap keys, such as
+// "/address/city"; the parents of nested paths must exist in the target document.
+// Fields tagged json:"-" are left out, as ApplyMergePatch never sets them.
+func ToJSONPatch(obj any) []PatchOperation {
+	operations := []PatchOperation{}
+	walkDirty(obj, true, func(path []string, value any, null bool) {
+		pointer := make([]string, len(path))
+		for i, key := range path {
+			pointer[i] = escapePointer(key)
+		}
+		operation := PatchOperation{Op: "add", Path: "/" + strings.Join(pointer, "/"), Value: value}
+		if null {
+			operation.Op, operation.Value = "remove", nil
+		}
+		operations = append(operations, operation)
+	})
+	return operations
+}
+
+// escapePointer escapes a JSON Pointer reference token (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+func pointerOrRoot(path string) string {
+	if path == "" {
+		return "patch"
+	}
+	return path
+}
+
+func isNullJSON(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
@@ -0,0 +1,173 @@
+package mut
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoChanges is returned by UpdateSQL when the struct has no dirty fields.
+	ErrNoChanges = errors.New("mut: no dirty fields to update")
+	// ErrNoWhere is returned by UpdateSQL without WHERE conditions, which would update
+	// every row of the table.
+	ErrNoWhere = errors.New("mut: update without where conditions")
+)
+
+// Placeholder is the bind parameter style of a SQL dialect.
+type Placeholder int
+
+const (
+	// Dollar numbers parameters as $1, $2, ... (PostgreSQL).
+	Dollar Placeholder = iota
+	// Question uses ? for every parameter (MySQL, SQLite).
+	Question
+	// AtName names parameters after their column, as @name, and passes the arguments
+	// as sql.NamedArg (SQL Server). WHERE parameters are prefixed with "where_".
+	AtName
+)
+
+// SQLOptions configures UpdateSQL.
+type SQLOptions struct {
+	// Placeholder is the bind parameter style. Default: Dollar.
+	Placeholder Placeholder
+}
+
+type SQLOption func(*SQLOptions)
+
+// WithPlaceholder sets the bind parameter style.
+func WithPlaceholder(placeholder Placeholder) SQLOption {
+	return func(o *SQLOptions) { o.Placeholder = placeholder }
+}
+
+// UpdateSQL builds a parameterized UPDATE statement setting the dirty Mut fields of
+// obj, with where as the equality conditions of the WHERE clause, sorted by column:
+//
+//	query, args, err := mut.UpdateSQL("users", dto, map[string]any{"id": id})
+//	// UPDATE users SET name = $1, age = $2 WHERE id = $3
+//
+// Columns come from the `db` tag, falling back to the json name and then the field
+// name; `db:"-"` skips a field. Embedded structs are flattened and explicit nulls are
+// set to NULL. The table and column names are written as is and must not come from
+// user input.
+func UpdateSQL(table string, obj any, where map[string]any, options ...SQLOption) (string, []any, error) {
+	o := SQLOptions{}
+	for _, option := range options {
+		if option != nil {
+			option(&o)
+		}
+	}
+	if len(where) == 0 {
+		return "", nil, ErrNoWhere
+	}
+
+	columns, values := dirtyColumns(obj)
+	if len(columns) == 0 {
+		return "", nil, ErrNoChanges
+	}
+
+	var args []any
+	bind := func(name string, value any) string {
+		switch o.Placeholder {
+		case Question:
+			args = append(args, value)
+			return "?"
+		case AtName:
+			args = append(args, sql.Named(name, value))
+			return "@" + name
+		default:
+			args = append(args, value)
+			return "$" + strconv.Itoa(len(args))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("UPDATE ")
+	b.WriteString(table)
+	b.WriteString(" SET ")
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s = %s", column, bind(column, values[i]))
+	}
+
+	keys := make([]string, 0, len(where))
+	for key := range where {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	b.WriteString(" WHERE ")
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(" AND ")
+		}
+		fmt.Fprintf(&b, "%s = %s", key, bind("where_"+key, where[key]))
+	}
+	return b.String(), args, nil
+}
+
+// dirtyColumns returns the columns and values of the dirty top-level Mut fields of
+// obj, in declaration order.
+func dirtyColumns(obj any) ([]string, []any) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	var columns []string
+	var values []any
+	var collect func(v reflect.Value)
+	collect = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Tag.Get("db") == "-" {
+				continue
+			}
+			fv := v.Field(i)
+			if promotesFields(field) {
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				collect(fv)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			m, ok := fv.Addr().Interface().(mutable)
+			if !ok {
+				continue
+			}
+			if !m.Dirty() {
+				continue
+			}
+			column := strings.Split(field.Tag.Get("db"), ",")[0]
+			if column == "" {
+				column = fieldKey(field)
+			}
+			columns = append(columns, column)
+			if m.IsNull() {
+				values = append(values, nil)
+			} else {
+				values = append(values, m.GetAny())
+			}
+		}
+	}
+	collect(addressable(v))
+	return columns, values
+}
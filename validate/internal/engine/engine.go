@@ -303,14 +303,38 @@ func reflectValueToAST(value reflect.Value, options schema.Options) (ast.Value,
 		}
 
 		object := make(map[string]ast.Value)
+		promoted := make(map[string]ast.Value)
 		typeValue := value.Type()
 		for index := 0; index < typeValue.NumField(); index++ {
 			field := typeValue.Field(index)
+			tag := reflection.ParseJSONTag(field.Tag.Get("json"))
+
+			// Fields of embedded structs without a json name are promoted, as in encoding/json.
+			if field.Anonymous && !tag.Ignored && tag.Name == "" {
+				embedded := value.Field(index)
+				if embedded.Kind() == reflect.Pointer {
+					if embedded.IsNil() || embedded.Type().Elem().Kind() != reflect.Struct {
+						continue
+					}
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					entry, err := reflectValueToAST(embedded, options)
+					if err != nil {
+						return ast.Value{}, err
+					}
+					for name, promotedValue := range entry.Object {
+						if _, exists := promoted[name]; !exists {
+							promoted[name] = promotedValue
+						}
+					}
+					continue
+				}
+			}
+
 			if field.PkgPath != "" {
 				continue
 			}
-
-			tag := reflection.ParseJSONTag(field.Tag.Get("json"))
 			if tag.Ignored {
 				continue
 			}
@@ -334,6 +358,11 @@ func reflectValueToAST(value reflect.Value, options schema.Options) (ast.Value,
 			}
 			object[name] = entry
 		}
+		for name, promotedValue := range promoted {
+			if _, exists := object[name]; !exists {
+				object[name] = promotedValue
+			}
+		}
 		return ast.ObjectValue(object), nil
 
 	default:
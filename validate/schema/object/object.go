@@ -92,33 +92,14 @@ func newField[T any](structPointer *T, fieldPointer any, validator func(context
 		return field[T]{}, fmt.Errorf("fieldPointer must be a pointer")
 	}
 
-	structType := structValue.Elem().Type()
-
-	base := unsafe.Pointer(structValue.Pointer())
-	fieldAddr := unsafe.Pointer(fieldValue.Pointer())
-
-	var matched reflect.StructField
-	var matchedOffset uintptr
-	found := false
-
-	for i := 0; i < structType.NumField(); i++ {
-		sf := structType.Field(i)
-
-		if sf.Anonymous {
-			continue
-		}
-
-		addr := unsafe.Pointer(uintptr(base) + sf.Offset)
-		if addr == fieldAddr {
-			matched = sf
-			matchedOffset = sf.Offset
-			found = true
-			break
-		}
+	offset := fieldValue.Pointer() - structValue.Pointer()
+	matched, matchedOffset, found, err := matchField(structValue.Elem().Type(), offset, fieldValue.Type().Elem())
+	if err != nil {
+		return field[T]{}, err
 	}
 
 	if !found {
-		return field[T]{}, fmt.Errorf("failed to resolve field (pointer does not match any direct field)")
+		return field[T]{}, fmt.Errorf("failed to resolve field (pointer does not match any direct or promoted field)")
 	}
 
 	name := jsonName(matched)
@@ -193,33 +174,14 @@ func resolveFieldInfo[T any](structPointer *T, fieldPointer any) (fieldInfo[T],
 		return fieldInfo[T]{}, fmt.Errorf("fieldPointer must be a pointer")
 	}
 
-	structType := structValue.Elem().Type()
-
-	base := unsafe.Pointer(structValue.Pointer())
-	fieldAddr := unsafe.Pointer(fieldValue.Pointer())
-
-	var matched reflect.StructField
-	var matchedOffset uintptr
-	found := false
-
-	for i := 0; i < structType.NumField(); i++ {
-		sf := structType.Field(i)
-
-		if sf.Anonymous {
-			continue
-		}
-
-		addr := unsafe.Pointer(uintptr(base) + sf.Offset)
-		if addr == fieldAddr {
-			matched = sf
-			matchedOffset = sf.Offset
-			found = true
-			break
-		}
+	offset := fieldValue.Pointer() - structValue.Pointer()
+	matched, matchedOffset, found, err := matchField(structValue.Elem().Type(), offset, fieldValue.Type().Elem())
+	if err != nil {
+		return fieldInfo[T]{}, err
 	}
 
 	if !found {
-		return fieldInfo[T]{}, fmt.Errorf("failed to resolve field (pointer does not match any direct field)")
+		return fieldInfo[T]{}, fmt.Errorf("failed to resolve field (pointer does not match any direct or promoted field)")
 	}
 
 	name := jsonName(matched)
@@ -325,6 +287,51 @@ func buildAssignFn(fieldType reflect.Type, offset uintptr) func(unsafe.Pointer,
 	}
 }
 
+// matchField finds the field of structType at offset with type fieldType, looking
+// into structs embedded by value without a json name, whose fields are promoted like
+// encoding/json does. Those embedded structs cannot be declared as fields, and the
+// fields of structs embedded by pointer cannot be resolved.
+func matchField(structType reflect.Type, offset uintptr, fieldType reflect.Type) (reflect.StructField, uintptr, bool, error) {
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+
+		if isPromoting(sf) {
+			if offset == sf.Offset && fieldType == sf.Type {
+				return reflect.StructField{}, 0, false, fmt.Errorf("embedded field %s cannot be declared, declare its promoted fields instead", sf.Name)
+			}
+			if sf.Type.Kind() != reflect.Struct || offset < sf.Offset || offset >= sf.Offset+sf.Type.Size() {
+				continue
+			}
+			promoted, promotedOffset, found, err := matchField(sf.Type, offset-sf.Offset, fieldType)
+			if found || err != nil {
+				return promoted, sf.Offset + promotedOffset, found, err
+			}
+			continue
+		}
+
+		if offset == sf.Offset {
+			return sf, sf.Offset, true, nil
+		}
+	}
+	return reflect.StructField{}, 0, false, nil
+}
+
+// isPromoting reports whether f is an embedded struct, or pointer to one, without a
+// json name, whose fields encoding/json promotes to the parent object.
+func isPromoting(f reflect.StructField) bool {
+	if !f.Anonymous {
+		return false
+	}
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" {
+		return false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
 func jsonName(f reflect.StructField) string {
 	tag := f.Tag.Get("json")
 	if tag == "-" {
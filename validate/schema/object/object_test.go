@@ -511,3 +511,66 @@ func TestFieldBuilder_BooleanSchema(t *testing.T) {
 		t.Fatalf("expected Active to be true")
 	}
 }
+
+type EmbeddedBase struct {
+	Tenant string `json:"tenant"`
+}
+
+type EmbeddedSample struct {
+	EmbeddedBase
+	Name string `json:"name"`
+}
+
+type EmbeddedPointerSample struct {
+	*EmbeddedBase
+	Name string `json:"name"`
+}
+
+func TestObject_PromotedFields(t *testing.T) {
+	s := object.New(func(target *EmbeddedSample, schemaValue *object.Schema[EmbeddedSample]) {
+		schemaValue.Field(&target.Tenant).Text().Required().Min(3)
+		schemaValue.Field(&target.Name).Text()
+	})
+
+	out, err := s.Validate(json.RawMessage(`{"tenant": "acme", "name": "x"}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out.Tenant != "acme" || out.Name != "x" {
+		t.Fatalf("expected promoted field decoded, got %+v", out)
+	}
+
+	_, err = s.Validate(json.RawMessage(`{"tenant": "a"}`))
+	validationError := testkit.RequireValidationError(t, err)
+	if validationError.Issues[0].Path != "tenant" {
+		t.Fatalf("expected issue on tenant, got %+v", validationError.Issues)
+	}
+
+	embedded := object.New(func(target *EmbeddedSample, schemaValue *object.Schema[EmbeddedSample]) {
+		schemaValue.Field(&target.EmbeddedBase).Text()
+	})
+	if _, err := embedded.Validate(json.RawMessage(`{}`)); err == nil {
+		t.Fatal("expected build error for an embedded field")
+	}
+	pointer := object.New(func(target *EmbeddedPointerSample, schemaValue *object.Schema[EmbeddedPointerSample]) {
+		schemaValue.Field(&target.EmbeddedBase).Text()
+	})
+	if _, err := pointer.Validate(json.RawMessage(`{}`)); err == nil {
+		t.Fatal("expected build error for an embedded pointer field")
+	}
+}
+
+func TestObject_PromotedFields_StructInput(t *testing.T) {
+	s := object.New(func(target *EmbeddedSample, schemaValue *object.Schema[EmbeddedSample]) {
+		schemaValue.Field(&target.Tenant).Text().Required()
+		schemaValue.Field(&target.Name).Text()
+	})
+
+	out, err := s.Validate(EmbeddedSample{EmbeddedBase: EmbeddedBase{Tenant: "acme"}, Name: "x"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if out.Tenant != "acme" || out.Name != "x" {
+		t.Fatalf("expected embedded struct flattened, got %+v", out)
+	}
+}
@@ -175,6 +175,11 @@ func Register(schemaValue AnySchema) {
 	registry.Register(schemaValue)
 }
 
+// Lookup returns the schema registered for the output type, if any.
+func Lookup(outputType reflect.Type) (AnySchema, bool) {
+	return registry.Lookup(outputType)
+}
+
 // ResetRegistry clears all registered schemas. Useful for testing.
 func ResetRegistry() {
 	registry.Reset()